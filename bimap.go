@@ -0,0 +1,53 @@
+package hashmap
+
+// BiMap is a one-to-one map that supports lookup by key and by value.
+// It is not thread-safe.
+// The zero value is an empty map ready to use.
+type BiMap[K Comparable[K], V Comparable[V]] struct {
+	forward Map[K, V]
+	inverse Map[V, K]
+}
+
+// Size returns the number of key/value pairs in the map.
+func (m *BiMap[K, V]) Size() int {
+	return m.forward.Size()
+}
+
+// Put associates the given key with the given value.
+// Any existing pairs with the same key or the same value are removed first.
+func (m *BiMap[K, V]) Put(key K, val V) {
+	if old, ok := m.forward.GetAndRemove(key); ok {
+		m.inverse.Remove(old)
+	}
+	if old, ok := m.inverse.GetAndRemove(val); ok {
+		m.forward.Remove(old)
+	}
+	m.forward.Put(key, val)
+	m.inverse.Put(val, key)
+}
+
+// GetByKey returns the value associated with the given key.
+// The second return value indicates if the key was found.
+func (m *BiMap[K, V]) GetByKey(key K) (V, bool) {
+	return m.forward.Get(key)
+}
+
+// GetByValue returns the key associated with the given value.
+// The second return value indicates if the value was found.
+func (m *BiMap[K, V]) GetByValue(val V) (K, bool) {
+	return m.inverse.Get(val)
+}
+
+// Remove removes the given key and its value from the map.
+func (m *BiMap[K, V]) Remove(key K) {
+	if val, ok := m.forward.GetAndRemove(key); ok {
+		m.inverse.Remove(val)
+	}
+}
+
+// RemoveByValue removes the given value and its key from the map.
+func (m *BiMap[K, V]) RemoveByValue(val V) {
+	if key, ok := m.inverse.GetAndRemove(val); ok {
+		m.forward.Remove(key)
+	}
+}
@@ -0,0 +1,59 @@
+package hashmap
+
+import "testing"
+
+// checkBijection verifies that the forward and inverse maps of m mirror each other.
+func checkBijection(t *testing.T, m *BiMap[String, Int]) {
+	t.Helper()
+	if m.forward.Size() != m.inverse.Size() {
+		t.Fatalf("forward size %d differs from inverse size %d", m.forward.Size(), m.inverse.Size())
+	}
+	for k, v := range m.forward.All() {
+		if got, ok := m.inverse.Get(v); !ok || got != k {
+			t.Fatalf("expected value %d to map back to key %s, got %s", v, k, got)
+		}
+	}
+}
+
+func TestBiMap(t *testing.T) {
+	m := BiMap[String, Int]{}
+	m.Put("one", 1)
+	m.Put("two", 2)
+	m.Put("three", 3)
+	checkBijection(t, &m)
+	if v, ok := m.GetByKey("two"); !ok || v != 2 {
+		t.Errorf("expected value 2, got %d", v)
+	}
+	if k, ok := m.GetByValue(3); !ok || k != "three" {
+		t.Errorf("expected key three, got %s", k)
+	}
+
+	m.Put("one", 11)
+	checkBijection(t, &m)
+	if _, ok := m.GetByValue(1); ok {
+		t.Errorf("expected old value 1 to be removed")
+	}
+
+	m.Put("deux", 2)
+	checkBijection(t, &m)
+	if _, ok := m.GetByKey("two"); ok {
+		t.Errorf("expected old key two to be removed")
+	}
+
+	m.Put("one", 3)
+	checkBijection(t, &m)
+	if m.Size() != 2 {
+		t.Errorf("expected size 2, got %d", m.Size())
+	}
+	if _, ok := m.GetByKey("three"); ok {
+		t.Errorf("expected key three to lose its value to one")
+	}
+
+	m.Remove("one")
+	m.RemoveByValue(2)
+	m.Remove("missing")
+	checkBijection(t, &m)
+	if m.Size() != 0 {
+		t.Errorf("expected size 0, got %d", m.Size())
+	}
+}
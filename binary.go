@@ -0,0 +1,147 @@
+package hashmap
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// UnsupportedTypeError is returned when a key or value type
+// does not implement the interface required for encoding or decoding.
+type UnsupportedTypeError struct {
+	Type      string
+	Interface string
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("hashmap: type %s does not implement %s", e.Type, e.Interface)
+}
+
+var errShortBuffer = errors.New("hashmap: binary data too short")
+
+func appendBinary(buf []byte, v any) ([]byte, error) {
+	bm, ok := v.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, &UnsupportedTypeError{fmt.Sprintf("%T", v), "encoding.BinaryMarshaler"}
+	}
+	data, err := bm.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > math.MaxUint32 {
+		return nil, errors.New("hashmap: element too large")
+	}
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	return append(buf, data...), nil
+}
+
+func readBinary(data []byte, v any) ([]byte, error) {
+	bu, ok := v.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return nil, &UnsupportedTypeError{fmt.Sprintf("%T", v), "encoding.BinaryUnmarshaler"}
+	}
+	if len(data) < 4 {
+		return nil, errShortBuffer
+	}
+	n := binary.BigEndian.Uint32(data)
+	data = data[4:]
+	if uint64(len(data)) < uint64(n) {
+		return nil, errShortBuffer
+	}
+	if err := bu.UnmarshalBinary(data[:n]); err != nil {
+		return nil, err
+	}
+	return data[n:], nil
+}
+
+func readCount(data []byte) (int, []byte, error) {
+	if len(data) < 4 {
+		return 0, nil, errShortBuffer
+	}
+	return int(binary.BigEndian.Uint32(data)), data[4:], nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The map is encoded as a 4-byte element count followed by
+// length-prefixed keys and values in unspecified order.
+// K and V must implement encoding.BinaryMarshaler.
+func (m *Map[K, V]) MarshalBinary() ([]byte, error) {
+	buf := binary.BigEndian.AppendUint32(nil, uint32(m.size))
+	var err error
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			if buf, err = appendBinary(buf, entry.key); err != nil {
+				return nil, err
+			}
+			if buf, err = appendBinary(buf, entry.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It decodes data produced by MarshalBinary and adds the entries to the map.
+// *K and *V must implement encoding.BinaryUnmarshaler.
+func (m *Map[K, V]) UnmarshalBinary(data []byte) error {
+	n, data, err := readCount(data)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		var key K
+		var value V
+		if data, err = readBinary(data, &key); err != nil {
+			return err
+		}
+		if data, err = readBinary(data, &value); err != nil {
+			return err
+		}
+		m.Put(key, value)
+	}
+	if len(data) != 0 {
+		return errors.New("hashmap: trailing binary data")
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The set is encoded as a 4-byte element count followed by
+// length-prefixed keys in unspecified order.
+// K must implement encoding.BinaryMarshaler.
+func (s *Set[K]) MarshalBinary() ([]byte, error) {
+	buf := binary.BigEndian.AppendUint32(nil, uint32(s.size))
+	var err error
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			if buf, err = appendBinary(buf, entry.key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It decodes data produced by MarshalBinary and adds the keys to the set.
+// *K must implement encoding.BinaryUnmarshaler.
+func (s *Set[K]) UnmarshalBinary(data []byte) error {
+	n, data, err := readCount(data)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		var key K
+		if data, err = readBinary(data, &key); err != nil {
+			return err
+		}
+		s.Add(key)
+	}
+	if len(data) != 0 {
+		return errors.New("hashmap: trailing binary data")
+	}
+	return nil
+}
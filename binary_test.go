@@ -0,0 +1,72 @@
+package hashmap
+
+import (
+	"errors"
+	"testing"
+	"testing/quick"
+)
+
+func TestMapBinaryRoundTrip(t *testing.T) {
+	f := func(native map[string]int) bool {
+		m := Map[String, Int]{}
+		for k, v := range native {
+			m.Put(String(k), Int(v))
+		}
+		data, err := m.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		var r Map[String, Int]
+		if err := r.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+			return false
+		}
+		return r.Equals(&m)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetBinaryRoundTrip(t *testing.T) {
+	f := func(keys []string) bool {
+		s := Set[String]{}
+		for _, k := range keys {
+			s.Add(String(k))
+		}
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		var r Set[String]
+		if err := r.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+			return false
+		}
+		return r.Equals(&s)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	m := Map[Float64, Int]{}
+	m.Put(1, 1)
+	var ute *UnsupportedTypeError
+	if _, err := m.MarshalBinary(); !errors.As(err, &ute) {
+		t.Errorf("expected UnsupportedTypeError, got %v", err)
+	}
+	if err := new(Map[Float64, Int]).UnmarshalBinary([]byte{0, 0, 0, 1, 0, 0, 0, 0}); !errors.As(err, &ute) {
+		t.Errorf("expected UnsupportedTypeError, got %v", err)
+	}
+	data, _ := intSet(1, 2).MarshalBinary()
+	if err := new(Set[Int]).UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("expected error for truncated data")
+	}
+	if err := new(Set[Int]).UnmarshalBinary(append(data, 0)); err == nil {
+		t.Errorf("expected error for trailing data")
+	}
+}
@@ -0,0 +1,69 @@
+package hashmap
+
+import "context"
+
+// ContextCheckInterval is the number of elements that ForEachCtx visits
+// between checks of the context. Values below 1 mean that the context
+// is checked before every element.
+var ContextCheckInterval = 64
+
+// contextCheckInterval returns ContextCheckInterval, or 1 if it is below 1.
+func contextCheckInterval() int {
+	return max(ContextCheckInterval, 1)
+}
+
+// ForEachCtx is like ForEach, but it also stops when ctx is done.
+// The context is checked before the first element and then every ContextCheckInterval elements;
+// if it is done, ForEachCtx returns the context's error.
+func (m *Map[K, V]) ForEachCtx(ctx context.Context, f func(K, V) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	interval := contextCheckInterval()
+	n := 0
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			n++
+			if n%interval == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			if err := f(entry.key, entry.value); err != nil {
+				if err == ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ForEachCtx is like ForEach, but it also stops when ctx is done.
+// The context is checked before the first element and then every ContextCheckInterval elements;
+// if it is done, ForEachCtx returns the context's error.
+func (s *Set[K]) ForEachCtx(ctx context.Context, f func(K) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	interval := contextCheckInterval()
+	n := 0
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			n++
+			if n%interval == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			if err := f(entry.key); err != nil {
+				if err == ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
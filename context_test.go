@@ -0,0 +1,98 @@
+package hashmap
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMapForEachCtx(t *testing.T) {
+	m := Map[Int, Int]{}
+	for i := 0; i < 1000; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	n := 0
+	if err := m.ForEachCtx(context.Background(), func(k, v Int) error { n++; return nil }); err != nil || n != 1000 {
+		t.Errorf("expected 1000 calls and nil error, got %d and %v", n, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	n = 0
+	err := m.ForEachCtx(ctx, func(k, v Int) error {
+		n++
+		if n == 100 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n >= 100+ContextCheckInterval {
+		t.Errorf("expected iteration to stop within %d calls of cancel, got %d calls", ContextCheckInterval, n)
+	}
+	n = 0
+	if err := m.ForEachCtx(ctx, func(k, v Int) error { n++; return nil }); !errors.Is(err, context.Canceled) || n != 0 {
+		t.Errorf("expected no calls on a cancelled context, got %d and %v", n, err)
+	}
+}
+
+func TestSetForEachCtx(t *testing.T) {
+	s := Set[Int]{}
+	for i := 0; i < 1000; i++ {
+		s.Add(Int(i))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	n := 0
+	err := s.ForEachCtx(ctx, func(k Int) error {
+		n++
+		if n == 100 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n >= 100+ContextCheckInterval {
+		t.Errorf("expected iteration to stop within %d calls of cancel, got %d calls", ContextCheckInterval, n)
+	}
+	n = 0
+	err = intSet(1, 2, 3).ForEachCtx(context.Background(), func(k Int) error { n++; return ErrStopIteration })
+	if err != nil || n != 1 {
+		t.Errorf("expected 1 call and nil error, got %d and %v", n, err)
+	}
+}
+
+func TestForEachCtxCheckInterval(t *testing.T) {
+	defer func(interval int) { ContextCheckInterval = interval }(ContextCheckInterval)
+	m := Map[Int, Int]{}
+	s := Set[Int]{}
+	for i := 0; i < 100; i++ {
+		m.Put(Int(i), Int(i))
+		s.Add(Int(i))
+	}
+	for _, interval := range []int{1, 0, -1} {
+		ContextCheckInterval = interval
+		ctx, cancel := context.WithCancel(context.Background())
+		n := 0
+		err := m.ForEachCtx(ctx, func(k, v Int) error {
+			n++
+			if n == 10 {
+				cancel()
+			}
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) || n != 10 {
+			t.Errorf("interval %d: expected 10 map calls and context.Canceled, got %d and %v", interval, n, err)
+		}
+		n = 0
+		err = s.ForEachCtx(ctx, func(k Int) error { n++; return nil })
+		if !errors.Is(err, context.Canceled) || n != 0 {
+			t.Errorf("interval %d: expected no set calls and context.Canceled, got %d and %v", interval, n, err)
+		}
+		n = 0
+		if err := s.ForEachCtx(context.Background(), func(k Int) error { n++; return nil }); err != nil || n != 100 {
+			t.Errorf("interval %d: expected 100 set calls and nil error, got %d and %v", interval, n, err)
+		}
+	}
+}
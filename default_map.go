@@ -0,0 +1,48 @@
+package hashmap
+
+// DefaultMap is a map that creates a value with a factory function
+// when a missing key is looked up, like Python's defaultdict.
+// It is not thread-safe.
+type DefaultMap[K Comparable[K], V any] struct {
+	m       Map[K, V]
+	factory func(K) V
+}
+
+// NewDefaultMap returns a new, empty default map that uses factory to create missing values.
+func NewDefaultMap[K Comparable[K], V any](factory func(K) V) *DefaultMap[K, V] {
+	return &DefaultMap[K, V]{factory: factory}
+}
+
+// Size returns the number of elements in the map.
+func (d *DefaultMap[K, V]) Size() int {
+	return d.m.Size()
+}
+
+// Get returns the value associated with the given key.
+// If the key is not in the map, the result of calling the factory with the key
+// is stored in the map and returned. The factory must not modify the map.
+func (d *DefaultMap[K, V]) Get(key K) V {
+	return d.m.ComputeIfAbsent(key, d.factory)
+}
+
+// GetWithoutDefault returns the value associated with the given key
+// and whether the key was found, without calling the factory.
+func (d *DefaultMap[K, V]) GetWithoutDefault(key K) (V, bool) {
+	return d.m.Get(key)
+}
+
+// Put associates the given value with the given key.
+func (d *DefaultMap[K, V]) Put(key K, value V) {
+	d.m.Put(key, value)
+}
+
+// Remove removes the given key from the map.
+func (d *DefaultMap[K, V]) Remove(key K) {
+	d.m.Remove(key)
+}
+
+// ForEach calls the given function for each key/value pair in the map.
+// It behaves like Map.ForEach and does not call the factory.
+func (d *DefaultMap[K, V]) ForEach(f func(K, V) error) error {
+	return d.m.ForEach(f)
+}
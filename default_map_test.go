@@ -0,0 +1,34 @@
+package hashmap
+
+import "testing"
+
+func TestDefaultMap(t *testing.T) {
+	calls := 0
+	d := NewDefaultMap(func(k String) []String {
+		calls++
+		return []String{k}
+	})
+	if _, ok := d.GetWithoutDefault("a"); ok || calls != 0 {
+		t.Errorf("expected GetWithoutDefault not to create a value")
+	}
+	if v := d.Get("a"); len(v) != 1 || v[0] != "a" {
+		t.Errorf("expected [a], got %v", v)
+	}
+	d.Put("a", append(d.Get("a"), "b"))
+	if v := d.Get("a"); len(v) != 2 || calls != 1 {
+		t.Errorf("expected [a b] from a single factory call, got %v after %d calls", v, calls)
+	}
+	if v, ok := d.GetWithoutDefault("a"); !ok || len(v) != 2 {
+		t.Errorf("expected stored value [a b], got %v, %v", v, ok)
+	}
+	d.Remove("a")
+	if d.Size() != 0 {
+		t.Errorf("expected empty map, got size %d", d.Size())
+	}
+	d.Get("b")
+	n := 0
+	d.ForEach(func(k String, v []String) error { n++; return nil })
+	if n != 1 || calls != 2 {
+		t.Errorf("expected 1 entry and 2 factory calls, got %d and %d", n, calls)
+	}
+}
@@ -2,8 +2,19 @@
 package hashmap
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"hash/maphash"
 	"math"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
 )
 
 // Comparable is an interface that must be implemented by all types that are used as keys in a Map or Set.
@@ -14,17 +25,35 @@ type Comparable[T any] interface {
 
 var seed maphash.Seed = maphash.MakeSeed()
 
+// saltFor derives the per-instance salt used by WithSeed from a seed.
+// The result is never zero, which means no salt.
+func saltFor(s maphash.Seed) uint64 {
+	return maphash.String(s, "hashmap salt") | 1
+}
+
+// salted mixes a salt into a hash before it is reduced to a home slot,
+// so that keys crafted to collide in one instance spread out in another.
+func salted(hash1, salt uint64) uint64 {
+	h := hash1 ^ salt
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
 func hash64bits(u uint64) uint64 {
 	h := maphash.Hash{}
 	h.SetSeed(seed)
 	h.WriteByte(byte(u))
-	h.WriteByte(byte(u << 8))
-	h.WriteByte(byte(u << 16))
-	h.WriteByte(byte(u << 24))
-	h.WriteByte(byte(u << 32))
-	h.WriteByte(byte(u << 40))
-	h.WriteByte(byte(u << 48))
-	h.WriteByte(byte(u << 56))
+	h.WriteByte(byte(u >> 8))
+	h.WriteByte(byte(u >> 16))
+	h.WriteByte(byte(u >> 24))
+	h.WriteByte(byte(u >> 32))
+	h.WriteByte(byte(u >> 40))
+	h.WriteByte(byte(u >> 48))
+	h.WriteByte(byte(u >> 56))
 	return h.Sum64()
 }
 
@@ -32,9 +61,9 @@ func hash32bits(u uint32) uint64 {
 	h := maphash.Hash{}
 	h.SetSeed(seed)
 	h.WriteByte(byte(u))
-	h.WriteByte(byte(u << 8))
-	h.WriteByte(byte(u << 16))
-	h.WriteByte(byte(u << 24))
+	h.WriteByte(byte(u >> 8))
+	h.WriteByte(byte(u >> 16))
+	h.WriteByte(byte(u >> 24))
 	return h.Sum64()
 }
 
@@ -42,7 +71,7 @@ func hash16bits(u uint16) uint64 {
 	h := maphash.Hash{}
 	h.SetSeed(seed)
 	h.WriteByte(byte(u))
-	h.WriteByte(byte(u << 8))
+	h.WriteByte(byte(u >> 8))
 	return h.Sum64()
 }
 
@@ -64,6 +93,20 @@ func (i Int) Equals(other Int) bool {
 	return i == other
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (i Int) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(nil, uint64(i)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return errors.New("hashmap: Int requires 8 bytes")
+	}
+	*i = Int(binary.BigEndian.Uint64(data))
+	return nil
+}
+
 // Int64 is a wrapper around int64 that implements the Comparable interface.
 type Int64 int64
 
@@ -192,6 +235,13 @@ func (u Uint8) Equals(other Uint8) bool {
 	return u == other
 }
 
+// MarshalJSON implements json.Marshaler. Without it, encoding/json would
+// encode a []Uint8, such as the elements of a Set[Uint8], as a base64 string
+// rather than an array of numbers.
+func (u Uint8) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(u), 10), nil
+}
+
 // Byte is an alias for Uint8.
 type Byte = Uint8
 
@@ -200,9 +250,9 @@ type Bool bool
 
 func (b Bool) Hash() uint64 {
 	if b {
-		return hash8bits(0)
+		return hash8bits(1)
 	}
-	return hash8bits(1)
+	return hash8bits(0)
 }
 
 func (b Bool) Equals(other Bool) bool {
@@ -223,6 +273,28 @@ func (s String) Equals(other String) bool {
 	return s == other
 }
 
+// MarshalText implements encoding.TextMarshaler.
+func (s String) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *String) UnmarshalText(text []byte) error {
+	*s = String(text)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s String) MarshalBinary() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *String) UnmarshalBinary(data []byte) error {
+	*s = String(data)
+	return nil
+}
+
 // Bytes is a wrapper around []byte that implements the Comparable interface.
 type Bytes []byte
 
@@ -254,13 +326,13 @@ func (s Slice[T]) Hash() uint64 {
 	for _, v := range s {
 		u := v.Hash()
 		h.WriteByte(byte(u))
-		h.WriteByte(byte(u << 8))
-		h.WriteByte(byte(u << 16))
-		h.WriteByte(byte(u << 24))
-		h.WriteByte(byte(u << 32))
-		h.WriteByte(byte(u << 40))
-		h.WriteByte(byte(u << 48))
-		h.WriteByte(byte(u << 56))
+		h.WriteByte(byte(u >> 8))
+		h.WriteByte(byte(u >> 16))
+		h.WriteByte(byte(u >> 24))
+		h.WriteByte(byte(u >> 32))
+		h.WriteByte(byte(u >> 40))
+		h.WriteByte(byte(u >> 48))
+		h.WriteByte(byte(u >> 56))
 	}
 	return h.Sum64()
 }
@@ -281,19 +353,10 @@ func (s Slice[T]) Equals(other Slice[T]) bool {
 type Complex64 complex64
 
 func (c Complex64) Hash() uint64 {
-	h := maphash.Hash{}
-	h.SetSeed(seed)
-	u := math.Float32bits(real(c))
-	h.WriteByte(byte(u))
-	h.WriteByte(byte(u << 8))
-	h.WriteByte(byte(u << 16))
-	h.WriteByte(byte(u << 24))
-	u = math.Float32bits(imag(c))
-	h.WriteByte(byte(u))
-	h.WriteByte(byte(u << 8))
-	h.WriteByte(byte(u << 16))
-	h.WriteByte(byte(u << 24))
-	return h.Sum64()
+	b := NewHashBuilder()
+	b.WriteUint64(uint64(math.Float32bits(real(c))))
+	b.WriteUint64(uint64(math.Float32bits(imag(c))))
+	return b.Sum64()
 }
 
 func (c Complex64) Equals(other Complex64) bool {
@@ -304,29 +367,213 @@ func (c Complex64) Equals(other Complex64) bool {
 type Complex128 complex128
 
 func (c Complex128) Hash() uint64 {
+	b := NewHashBuilder()
+	b.WriteUint64(math.Float64bits(real(c)))
+	b.WriteUint64(math.Float64bits(imag(c)))
+	return b.Sum64()
+}
+
+func (c Complex128) Equals(other Complex128) bool {
+	return math.Float64bits(real(c)) == math.Float64bits(real(other)) && math.Float64bits(imag(c)) == math.Float64bits(imag(other))
+}
+
+// Timestamp is a wrapper around time.Time that implements the Comparable interface.
+// Two timestamps are equal if they represent the same instant,
+// even if they are in different locations.
+type Timestamp time.Time
+
+func (t Timestamp) Hash() uint64 {
+	return hash64bits(uint64(time.Time(t).UnixNano()))
+}
+
+func (t Timestamp) Equals(other Timestamp) bool {
+	return time.Time(t).Equal(time.Time(other))
+}
+
+// IPAddr is a wrapper around net.IP that implements the Comparable interface.
+// Unlike net.IP.Equal, equality is byte-exact, so the 4-byte and 16-byte forms
+// of the same IPv4 address are different keys. Use NewIPAddr to normalise
+// addresses so that every IPv4 address has a single representation.
+type IPAddr []byte
+
+// NewIPAddr returns ip as an IPAddr, converting IPv4 addresses to their 4-byte form.
+func NewIPAddr(ip net.IP) IPAddr {
+	if ip4 := ip.To4(); ip4 != nil {
+		return IPAddr(ip4)
+	}
+	return IPAddr(ip)
+}
+
+func (a IPAddr) Hash() uint64 {
 	h := maphash.Hash{}
 	h.SetSeed(seed)
-	u := math.Float64bits(real(c))
-	h.WriteByte(byte(u))
-	h.WriteByte(byte(u << 8))
-	h.WriteByte(byte(u << 16))
-	h.WriteByte(byte(u << 24))
-	h.WriteByte(byte(u << 32))
-	h.WriteByte(byte(u << 40))
-	h.WriteByte(byte(u << 48))
-	h.WriteByte(byte(u << 56))
-	u = math.Float64bits(imag(c))
-	h.WriteByte(byte(u))
-	h.WriteByte(byte(u << 8))
-	h.WriteByte(byte(u << 16))
-	h.WriteByte(byte(u << 24))
-	h.WriteByte(byte(u << 32))
-	h.WriteByte(byte(u << 40))
-	h.WriteByte(byte(u << 48))
-	h.WriteByte(byte(u << 56))
+	h.Write(a)
 	return h.Sum64()
 }
 
-func (c Complex128) Equals(other Complex128) bool {
-	return math.Float64bits(real(c)) == math.Float64bits(real(other)) && math.Float64bits(imag(c)) == math.Float64bits(imag(other))
+func (a IPAddr) Equals(other IPAddr) bool {
+	return bytes.Equal(a, other)
+}
+
+// UUID is a 16-byte array that implements the Comparable interface.
+type UUID [16]byte
+
+func (u UUID) Hash() uint64 {
+	h := maphash.Hash{}
+	h.SetSeed(seed)
+	h.Write(u[:])
+	return h.Sum64()
+}
+
+func (u UUID) Equals(other UUID) bool {
+	return u == other
+}
+
+// Hash256 is a 32-byte array, such as a SHA-256 digest, that implements the Comparable interface.
+type Hash256 [32]byte
+
+func (d Hash256) Hash() uint64 {
+	h := maphash.Hash{}
+	h.SetSeed(seed)
+	h.Write(d[:])
+	return h.Sum64()
+}
+
+func (d Hash256) Equals(other Hash256) bool {
+	return d == other
+}
+
+// MACAddr is a 6-byte (EUI-48) hardware address that implements the Comparable interface.
+type MACAddr [6]byte
+
+// NewMACAddr returns hw as a MACAddr.
+// It returns an error unless hw is 6 bytes long.
+func NewMACAddr(hw net.HardwareAddr) (MACAddr, error) {
+	var a MACAddr
+	if len(hw) != len(a) {
+		return a, errors.New("hashmap: MACAddr requires 6 bytes")
+	}
+	copy(a[:], hw)
+	return a, nil
+}
+
+func (a MACAddr) Hash() uint64 {
+	return hash64bits(uint64(a[0])<<40 | uint64(a[1])<<32 | uint64(a[2])<<24 | uint64(a[3])<<16 | uint64(a[4])<<8 | uint64(a[5]))
+}
+
+func (a MACAddr) Equals(other MACAddr) bool {
+	return a == other
+}
+
+// String returns the address in the canonical form, such as 00:11:22:33:44:55.
+func (a MACAddr) String() string {
+	return net.HardwareAddr(a[:]).String()
+}
+
+// BigInt is a wrapper around *big.Int that implements the Comparable interface.
+// The zero value represents 0.
+type BigInt struct {
+	v *big.Int
+}
+
+// NewBigInt returns a BigInt holding a copy of n.
+// A nil n is treated as 0.
+func NewBigInt(n *big.Int) BigInt {
+	if n == nil {
+		return BigInt{}
+	}
+	return BigInt{new(big.Int).Set(n)}
+}
+
+// Value returns a copy of the wrapped integer.
+func (b BigInt) Value() *big.Int {
+	if b.v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(b.v)
+}
+
+func (b BigInt) Hash() uint64 {
+	h := maphash.Hash{}
+	h.SetSeed(seed)
+	// The sign byte also distinguishes 0, whose Bytes are empty.
+	if b.v == nil {
+		h.WriteByte(1)
+		return h.Sum64()
+	}
+	h.WriteByte(byte(b.v.Sign() + 1))
+	h.Write(b.v.Bytes())
+	return h.Sum64()
+}
+
+func (b BigInt) Equals(other BigInt) bool {
+	// Compare in place: Equals runs on every probe step, so it must not copy.
+	switch {
+	case b.v == nil && other.v == nil:
+		return true
+	case b.v == nil:
+		return other.v.Sign() == 0
+	case other.v == nil:
+		return b.v.Sign() == 0
+	}
+	return b.v.Cmp(other.v) == 0
+}
+
+// Pointer is a wrapper around *T that implements the Comparable interface
+// with pointer identity: two Pointers are equal if they point to the same variable.
+// The pointed-to type need not be comparable. Go's garbage collector does not move
+// heap objects, so the address, and therefore the hash, stays the same.
+type Pointer[T any] struct {
+	p *T
+}
+
+// NewPointer returns a Pointer wrapping p.
+func NewPointer[T any](p *T) Pointer[T] {
+	return Pointer[T]{p}
+}
+
+// Get returns the wrapped pointer.
+func (p Pointer[T]) Get() *T {
+	return p.p
+}
+
+func (p Pointer[T]) Hash() uint64 {
+	return hash64bits(uint64(uintptr(unsafe.Pointer(p.p))))
+}
+
+func (p Pointer[T]) Equals(other Pointer[T]) bool {
+	return p.p == other.p
+}
+
+// CIString is a wrapper around string that implements the Comparable interface
+// with case-insensitive equality, as defined by strings.EqualFold.
+type CIString string
+
+func (s CIString) Hash() uint64 {
+	h := maphash.Hash{}
+	h.SetSeed(seed)
+	var buf [utf8.UTFMax]byte
+	for _, r := range string(s) {
+		// Hash the smallest rune that folds to r, so that all strings
+		// equal under strings.EqualFold hash the same.
+		if r < utf8.RuneSelf {
+			if 'a' <= r && r <= 'z' {
+				r -= 'a' - 'A'
+			}
+			h.WriteByte(byte(r))
+			continue
+		}
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < r {
+				r = f
+			}
+		}
+		n := utf8.EncodeRune(buf[:], r)
+		h.Write(buf[:n])
+	}
+	return h.Sum64()
+}
+
+func (s CIString) Equals(other CIString) bool {
+	return strings.EqualFold(string(s), string(other))
 }
@@ -0,0 +1,331 @@
+package hashmap
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHashUsesAllBytes(t *testing.T) {
+	if Int(1).Hash() == Int(256).Hash() {
+		t.Errorf("expected Int(1) and Int(256) to hash differently")
+	}
+	if Int(1).Hash() == Int(1<<56+1).Hash() {
+		t.Errorf("expected Int(1) and Int(1<<56+1) to hash differently")
+	}
+	if Int32(1).Hash() == Int32(1<<24+1).Hash() {
+		t.Errorf("expected Int32(1) and Int32(1<<24+1) to hash differently")
+	}
+	if Int16(1).Hash() == Int16(256+1).Hash() {
+		t.Errorf("expected Int16(1) and Int16(257) to hash differently")
+	}
+	if Complex64(1).Hash() == Complex64(2).Hash() {
+		t.Errorf("expected Complex64(1) and Complex64(2) to hash differently")
+	}
+	if Complex128(1+2i).Hash() == Complex128(2+1i).Hash() {
+		t.Errorf("expected Complex128(1+2i) and Complex128(2+1i) to hash differently")
+	}
+}
+
+func TestSliceHash(t *testing.T) {
+	if (Slice[Int]{1}).Hash() == (Slice[Int]{256}).Hash() {
+		t.Errorf("expected Slice{1} and Slice{256} to hash differently")
+	}
+	if (Slice[Int]{1, 2}).Hash() == (Slice[Int]{2, 1}).Hash() {
+		t.Errorf("expected Slice{1, 2} and Slice{2, 1} to hash differently")
+	}
+	// The element hashes differ only above the lowest byte.
+	if (Slice[collidingKey]{1}).Hash() == (Slice[collidingKey]{2}).Hash() {
+		t.Errorf("expected all bytes of the element hashes to be used")
+	}
+}
+
+func TestBoolHash(t *testing.T) {
+	if Bool(true).Hash() != Bool(true).Hash() {
+		t.Errorf("expected Bool(true) to hash consistently")
+	}
+	if Bool(true).Hash() == Bool(false).Hash() {
+		t.Errorf("expected Bool(true) and Bool(false) to hash differently")
+	}
+	if Bool(false).Hash() != Uint8(0).Hash() {
+		t.Errorf("expected Bool(false) to hash like Uint8(0)")
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	utc := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	local := utc.In(time.FixedZone("UTC+2", 2*60*60))
+	before := time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		a, b time.Time
+		r    bool
+	}{
+		{time.Time{}, time.Time{}, true},
+		{utc, local, true},
+		{before, before, true},
+		{before, utc, false},
+		{utc, utc.Add(time.Nanosecond), false},
+		{time.Time{}, utc, false},
+	}
+	for _, test := range tests {
+		a, b := Timestamp(test.a), Timestamp(test.b)
+		if a.Equals(b) != test.r {
+			t.Errorf("expected %v equals %v to be %v", test.a, test.b, test.r)
+		}
+		if test.r && a.Hash() != b.Hash() {
+			t.Errorf("expected %v and %v to hash equally", test.a, test.b)
+		}
+	}
+
+	m := Map[Timestamp, String]{}
+	m.Put(Timestamp(utc), "event")
+	if v, ok := m.Get(Timestamp(local)); !ok || v != "event" {
+		t.Errorf("expected to find the same instant in a different location")
+	}
+}
+
+func TestIPAddr(t *testing.T) {
+	v4 := net.ParseIP("192.0.2.1")
+	mapped := v4.To16()
+	if IPAddr(v4.To4()).Equals(IPAddr(mapped)) {
+		t.Errorf("expected 4-byte and 16-byte forms to be different keys")
+	}
+	if !NewIPAddr(v4.To4()).Equals(NewIPAddr(mapped)) {
+		t.Errorf("expected NewIPAddr to normalise IPv4 addresses")
+	}
+	if len(NewIPAddr(mapped)) != net.IPv4len {
+		t.Errorf("expected 4-byte form, got %d bytes", len(NewIPAddr(mapped)))
+	}
+	v6 := net.ParseIP("2001:db8::1")
+	if len(NewIPAddr(v6)) != net.IPv6len {
+		t.Errorf("expected 16-byte form for IPv6, got %d bytes", len(NewIPAddr(v6)))
+	}
+
+	s := Set[IPAddr]{}
+	s.Add(NewIPAddr(v4))
+	s.Add(NewIPAddr(v6))
+	if !s.Contains(NewIPAddr(net.ParseIP("192.0.2.1"))) {
+		t.Errorf("expected to find 192.0.2.1")
+	}
+	if !s.Contains(NewIPAddr(net.ParseIP("2001:db8:0::1"))) {
+		t.Errorf("expected to find 2001:db8::1")
+	}
+	if s.Contains(NewIPAddr(net.ParseIP("192.0.2.2"))) {
+		t.Errorf("expected to not find 192.0.2.2")
+	}
+}
+
+func TestUUID(t *testing.T) {
+	a := UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	b := a
+	b[15] = 0x01
+	if !a.Equals(a) || a.Equals(b) {
+		t.Errorf("unexpected UUID equality")
+	}
+	if a.Hash() == b.Hash() {
+		t.Errorf("expected UUIDs differing in the last byte to hash differently")
+	}
+	m := Map[UUID, Int]{}
+	m.Put(a, 1)
+	m.Put(b, 2)
+	if v, _ := m.Get(a); v != 1 {
+		t.Errorf("expected value 1, got %d", v)
+	}
+	if v, _ := m.Get(b); v != 2 {
+		t.Errorf("expected value 2, got %d", v)
+	}
+}
+
+func TestMACAddr(t *testing.T) {
+	tests := []struct {
+		s string
+		a MACAddr
+	}{
+		{"00:00:00:00:00:00", MACAddr{}},
+		{"ff:ff:ff:ff:ff:ff", MACAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{"01:00:5e:00:00:fb", MACAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0xfb}},
+	}
+	s := Set[MACAddr]{}
+	for _, test := range tests {
+		hw, err := net.ParseMAC(test.s)
+		if err != nil {
+			t.Fatalf("expected %s to parse, got %v", test.s, err)
+		}
+		a, err := NewMACAddr(hw)
+		if err != nil || !a.Equals(test.a) {
+			t.Errorf("expected %v, got %v, %v", test.a, a, err)
+		}
+		if a.String() != test.s {
+			t.Errorf("expected %s, got %s", test.s, a)
+		}
+		s.Add(a)
+	}
+	if s.Size() != len(tests) {
+		t.Errorf("expected %d distinct addresses, got %d", len(tests), s.Size())
+	}
+	if (MACAddr{0, 0, 0, 0, 0, 1}).Hash() == (MACAddr{1, 0, 0, 0, 0, 0}).Hash() {
+		t.Errorf("expected byte order to affect the hash")
+	}
+	hw, _ := net.ParseMAC("00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01")
+	if _, err := NewMACAddr(hw); err == nil {
+		t.Errorf("expected error for a 20-byte address")
+	}
+}
+
+func TestHash256(t *testing.T) {
+	a := Hash256(sha256.Sum256([]byte("a")))
+	b := Hash256(sha256.Sum256([]byte("b")))
+	if !a.Equals(Hash256(sha256.Sum256([]byte("a")))) || a.Equals(b) {
+		t.Errorf("unexpected Hash256 equality")
+	}
+	s := Set[Hash256]{}
+	s.Add(a)
+	if !s.Contains(a) || s.Contains(b) {
+		t.Errorf("unexpected Hash256 set membership")
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	m := Map[BigInt, Int]{}
+	for i := -10; i <= 10; i++ {
+		m.Put(NewBigInt(big.NewInt(int64(i))), Int(i))
+	}
+	if m.Size() != 21 {
+		t.Errorf("expected size 21, got %d", m.Size())
+	}
+	for i := -10; i <= 10; i++ {
+		if v, ok := m.Get(NewBigInt(big.NewInt(int64(i)))); !ok || v != Int(i) {
+			t.Errorf("expected value %d, got %d", i, v)
+		}
+	}
+	if v, ok := m.Get(BigInt{}); !ok || v != 0 {
+		t.Errorf("expected zero value BigInt to equal 0")
+	}
+	if NewBigInt(big.NewInt(0)).Hash() != (BigInt{}).Hash() {
+		t.Errorf("expected zero value BigInt to hash like 0")
+	}
+	if NewBigInt(big.NewInt(1)).Hash() == NewBigInt(big.NewInt(-1)).Hash() {
+		t.Errorf("expected 1 and -1 to hash differently")
+	}
+
+	n := big.NewInt(5)
+	b := NewBigInt(n)
+	n.SetInt64(6)
+	if !b.Equals(NewBigInt(big.NewInt(5))) {
+		t.Errorf("expected NewBigInt to copy its argument")
+	}
+
+	zero, one := NewBigInt(big.NewInt(0)), NewBigInt(big.NewInt(1))
+	tests := []struct {
+		a, b  BigInt
+		equal bool
+	}{
+		{BigInt{}, BigInt{}, true},
+		{BigInt{}, zero, true},
+		{zero, BigInt{}, true},
+		{BigInt{}, one, false},
+		{one, BigInt{}, false},
+		{one, NewBigInt(big.NewInt(1)), true},
+	}
+	for _, test := range tests {
+		if got := test.a.Equals(test.b); got != test.equal {
+			t.Errorf("expected %v.Equals(%v) to be %v", test.a.Value(), test.b.Value(), test.equal)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { one.Equals(b) }); n != 0 {
+		t.Errorf("expected Equals not to allocate, got %v allocations", n)
+	}
+}
+
+func TestPointer(t *testing.T) {
+	type node struct {
+		children []*node
+	}
+	a, b := &node{}, &node{}
+	m := Map[Pointer[node], Int]{}
+	m.Put(NewPointer(a), 1)
+	m.Put(NewPointer(b), 2)
+	m.Put(NewPointer(a), 3)
+	if m.Size() != 2 {
+		t.Errorf("expected 2 keys, got %d", m.Size())
+	}
+	if v, _ := m.Get(NewPointer(a)); v != 3 {
+		t.Errorf("expected 3, got %d", v)
+	}
+	if _, ok := m.Get(NewPointer(&node{})); ok {
+		t.Errorf("expected a new pointer not to be found")
+	}
+	if NewPointer(a).Get() != a {
+		t.Errorf("expected Get to return the wrapped pointer")
+	}
+	var zero Pointer[node]
+	if !zero.Equals(NewPointer[node](nil)) || zero.Get() != nil {
+		t.Errorf("expected the zero Pointer to wrap nil")
+	}
+}
+
+func TestCIString(t *testing.T) {
+	tests := []struct {
+		a, b CIString
+		r    bool
+	}{
+		{"Content-Type", "content-type", true},
+		{"CONTENT-TYPE", "Content-Type", true},
+		{"Content-Type", "Content-Length", false},
+		{"", "", true},
+		{"ſ", "S", true},
+		{"\u212a", "k", true},
+		{"Straße", "STRASSE", false},
+	}
+	for _, test := range tests {
+		if test.a.Equals(test.b) != test.r {
+			t.Errorf("expected %q equals %q to be %v", test.a, test.b, test.r)
+		}
+		if test.r && test.a.Hash() != test.b.Hash() {
+			t.Errorf("expected %q and %q to hash equally", test.a, test.b)
+		}
+	}
+}
+
+var headerNames = []string{
+	"Accept", "Accept-Encoding", "Accept-Language", "Authorization", "Cache-Control",
+	"Connection", "Content-Length", "Content-Type", "Cookie", "Host",
+	"If-Modified-Since", "If-None-Match", "Origin", "Referer", "User-Agent",
+}
+
+func BenchmarkHeaderLookupString(b *testing.B) {
+	m := Map[String, Int]{}
+	for i, name := range headerNames {
+		m.Put(String(name), Int(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range headerNames {
+			if _, ok := m.Get(String(name)); !ok {
+				b.Fatal("expected to find key")
+			}
+		}
+	}
+}
+
+func BenchmarkHeaderLookupCIString(b *testing.B) {
+	m := Map[CIString, Int]{}
+	for i, name := range headerNames {
+		m.Put(CIString(name), Int(i))
+	}
+	lower := make([]CIString, len(headerNames))
+	for i, name := range headerNames {
+		lower[i] = CIString(strings.ToLower(name))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range lower {
+			if _, ok := m.Get(name); !ok {
+				b.Fatal("expected to find key")
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package hashmap
+
+import "fmt"
+
+// Entry is a key/value pair stored in a Map.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// String implements fmt.Stringer.
+// The entry is formatted as key:value, as in Map.String.
+func (e Entry[K, V]) String() string {
+	return fmt.Sprintf("%v:%v", e.Key, e.Value)
+}
+
+// NewMapFromEntries returns a new map with the given entries.
+// Later entries overwrite earlier ones with the same key.
+func NewMapFromEntries[K Comparable[K], V any](entries []Entry[K, V], opts ...MapOption[K, V]) *Map[K, V] {
+	m := NewMap(len(entries), opts...)
+	m.PutAll(entries)
+	return m
+}
@@ -0,0 +1,35 @@
+package hashmap
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEntryString(t *testing.T) {
+	tests := []struct {
+		e Entry[String, Int]
+		s string
+	}{
+		{Entry[String, Int]{"a", 1}, "a:1"},
+		{Entry[String, Int]{}, ":0"},
+	}
+	for _, test := range tests {
+		if s := fmt.Sprint(test.e); s != test.s {
+			t.Errorf("expected %q, got %q", test.s, s)
+		}
+	}
+}
+
+func TestNewMapFromEntries(t *testing.T) {
+	m := NewMapFromEntries([]Entry[Int, Int]{{1, 10}, {2, 20}, {1, 11}})
+	if r := intMap(1, 11, 2, 20); !m.Equals(r) {
+		t.Errorf("expected %v, got %v", r, m)
+	}
+	if m := NewMapFromEntries[Int, Int](nil); m.Size() != 0 || m.Capacity() != 0 {
+		t.Errorf("expected empty map without backing array, got %v with capacity %d", m, m.Capacity())
+	}
+	m = NewMapFromEntries(m.Entries(), WithLoadFactor[Int, Int](0.5, 0.1))
+	if r := intMap(1, 11, 2, 20); !m.Equals(r) || m.maxLoadFactor() != 0.5 {
+		t.Errorf("expected %v with load factor 0.5, got %v", r, m)
+	}
+}
@@ -0,0 +1,117 @@
+package hashmap
+
+import (
+	"sync"
+	"time"
+)
+
+type expiringEntry[V any] struct {
+	value    V
+	deadline time.Time
+}
+
+// ExpiringMap is a map whose entries expire after a per-entry time to live.
+// Expired entries are deleted lazily when accessed, by PurgeExpired,
+// or by a background goroutine started by NewExpiringMap.
+// It is safe for concurrent use by multiple goroutines.
+// An ExpiringMap must be created with NewExpiringMap.
+type ExpiringMap[K Comparable[K], V any] struct {
+	mu      sync.Mutex
+	entries *Map[K, expiringEntry[V]]
+	now     func() time.Time
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewExpiringMap returns a new empty map with room for capacity entries.
+// If cleanupInterval is positive, a background goroutine calls PurgeExpired
+// at that interval until Close is called.
+func NewExpiringMap[K Comparable[K], V any](capacity int, cleanupInterval time.Duration) *ExpiringMap[K, V] {
+	m := &ExpiringMap[K, V]{
+		entries: NewMap[K, expiringEntry[V]](capacity),
+		now:     time.Now,
+		done:    make(chan struct{}),
+	}
+	if cleanupInterval > 0 {
+		go m.cleanup(cleanupInterval)
+	}
+	return m
+}
+
+func (m *ExpiringMap[K, V]) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.PurgeExpired()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// Close stops the background cleanup goroutine, if any.
+// The map remains usable after Close.
+func (m *ExpiringMap[K, V]) Close() {
+	m.once.Do(func() {
+		close(m.done)
+	})
+}
+
+// Size returns the number of entries in the map, including expired entries
+// that have not been deleted yet.
+func (m *ExpiringMap[K, V]) Size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.entries.Size()
+}
+
+// Put adds the given key/value pair to the map, expiring after ttl.
+// If the key already exists, its value and deadline are replaced.
+func (m *ExpiringMap[K, V]) Put(key K, val V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries.Put(key, expiringEntry[V]{val, m.now().Add(ttl)})
+}
+
+// Get returns the value associated with the given key if it has not expired.
+// The second return value indicates if the key was found.
+// Getting an expired key counts as a miss and deletes the entry.
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var zero V
+	e, ok := m.entries.Get(key)
+	if !ok {
+		return zero, false
+	}
+	if !m.now().Before(e.deadline) {
+		m.entries.Remove(key)
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Remove removes the given key from the map.
+func (m *ExpiringMap[K, V]) Remove(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries.Remove(key)
+}
+
+// PurgeExpired deletes all expired entries.
+func (m *ExpiringMap[K, V]) PurgeExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	var expired []K
+	for k, e := range m.entries.All() {
+		if !now.Before(e.deadline) {
+			expired = append(expired, k)
+		}
+	}
+	for _, k := range expired {
+		m.entries.Remove(k)
+	}
+}
@@ -0,0 +1,53 @@
+package hashmap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiringMap(t *testing.T) {
+	m := NewExpiringMap[String, Int](0, 0)
+	defer m.Close()
+	now := time.Unix(1000, 0)
+	m.now = func() time.Time { return now }
+
+	m.Put("a", 1, time.Second)
+	m.Put("b", 2, 3*time.Second)
+	m.Put("c", 3, 3*time.Second)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("expected value 1, got %d", v)
+	}
+
+	now = now.Add(2 * time.Second)
+	if _, ok := m.Get("a"); ok {
+		t.Errorf("expected key a to be expired")
+	}
+	if m.Size() != 2 {
+		t.Errorf("expected expired key a to be deleted on access, got size %d", m.Size())
+	}
+
+	m.Put("b", 22, 3*time.Second)
+	now = now.Add(2 * time.Second)
+	m.PurgeExpired()
+	if m.Size() != 1 {
+		t.Errorf("expected key c to be purged, got size %d", m.Size())
+	}
+	if v, ok := m.Get("b"); !ok || v != 22 {
+		t.Errorf("expected refreshed key b to survive, got %d", v)
+	}
+}
+
+func TestExpiringMapCleanup(t *testing.T) {
+	m := NewExpiringMap[String, Int](10, time.Millisecond)
+	defer m.Close()
+	m.Put("a", 1, time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Size() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected background cleanup to delete the expired entry")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	m.Close()
+	m.Close()
+}
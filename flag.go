@@ -0,0 +1,45 @@
+package hashmap
+
+import (
+	"flag"
+	"strings"
+)
+
+// StringSetFlag is a flag.Value that collects comma-separated strings into a set.
+// The flag can be repeated, as in -tag a,b -tag c.
+// A *StringSetFlag implements flag.Value, and its zero value is ready to use.
+// The set is a named field rather than embedded, because an embedded
+// *Set[String] would be a field named Set, which clashes with the Set method.
+type StringSetFlag struct {
+	Values *Set[String]
+}
+
+// Set adds each element of the comma-separated list s to the set,
+// creating the set if Values is nil. Empty elements are ignored.
+func (f *StringSetFlag) Set(s string) error {
+	if f.Values == nil {
+		f.Values = &Set[String]{}
+	}
+	for _, v := range strings.Split(s, ",") {
+		if v != "" {
+			f.Values.Add(String(v))
+		}
+	}
+	return nil
+}
+
+// String returns the elements of the set, sorted and separated by commas.
+func (f *StringSetFlag) String() string {
+	if f == nil || f.Values == nil {
+		return ""
+	}
+	return JoinSet(f.Values, ",")
+}
+
+// StringSetVar defines a StringSetFlag with the given name and usage in fs
+// and returns the set that collects its values.
+func StringSetVar(fs *flag.FlagSet, name, usage string) *Set[String] {
+	s := &Set[String]{}
+	fs.Var(&StringSetFlag{s}, name, usage)
+	return s
+}
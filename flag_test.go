@@ -0,0 +1,35 @@
+package hashmap
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestStringSetFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	tags := StringSetVar(fs, "tag", "tags to apply")
+	if err := fs.Parse([]string{"-tag", "b,a", "-tag", "c", "-tag", "a,,"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if s := JoinSet(tags, " "); s != "a b c" {
+		t.Errorf("expected a b c, got %q", s)
+	}
+	if s := fs.Lookup("tag").Value.String(); s != "a,b,c" {
+		t.Errorf("expected a,b,c, got %q", s)
+	}
+	if s := (&StringSetFlag{}).String(); s != "" {
+		t.Errorf("expected empty string for zero value, got %q", s)
+	}
+	var _ flag.Value = &StringSetFlag{}
+
+	var zero StringSetFlag
+	fs.Var(&zero, "label", "labels")
+	if err := fs.Parse([]string{"-label", "y,x"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if s := zero.String(); s != "x,y" {
+		t.Errorf("expected the zero StringSetFlag to collect x,y, got %q", s)
+	}
+}
@@ -0,0 +1,89 @@
+package hashmap
+
+// FrozenSet is a read-only set, created from a Set with Freeze.
+// It has no methods that modify it, so it is safe for concurrent use.
+type FrozenSet[K Comparable[K]] struct {
+	s *Set[K]
+}
+
+// Freeze returns a read-only copy of s. Later changes to s do not affect it.
+// The copy uses the smallest backing array that holds the keys at the default load factor.
+func Freeze[K Comparable[K]](s *Set[K]) *FrozenSet[K] {
+	c := s.Copy()
+	if n := capacityFor(c.size, defaultMaxLoadFactor); c.entries != nil && n < len(c.entries) {
+		c.resize(n)
+	}
+	return &FrozenSet[K]{c}
+}
+
+// Size returns the number of elements in the set.
+func (f *FrozenSet[K]) Size() int {
+	return f.s.Size()
+}
+
+// Contains returns true if the set contains the given key.
+func (f *FrozenSet[K]) Contains(key K) bool {
+	return f.s.Contains(key)
+}
+
+// ForEach calls the given function for each element of the set, as Set.ForEach does.
+func (f *FrozenSet[K]) ForEach(fn func(K) error) error {
+	return f.s.ForEach(fn)
+}
+
+// Hash returns the hash code for the set. It equals the hash of the set it was frozen from.
+func (f *FrozenSet[K]) Hash() uint64 {
+	return f.s.Hash()
+}
+
+// Equals returns true if the set is equal to the given set.
+func (f *FrozenSet[K]) Equals(other *FrozenSet[K]) bool {
+	return f.s.Equals(other.s)
+}
+
+// FrozenMap is a read-only map, created from a Map with FreezeMap.
+// It has no methods that modify it, so it is safe for concurrent use.
+type FrozenMap[K Comparable[K], V any] struct {
+	m *Map[K, V]
+}
+
+// FreezeMap returns a read-only copy of m. Later changes to m do not affect it.
+// The copy uses the smallest backing array that holds the entries at the map's maximum load factor.
+func FreezeMap[K Comparable[K], V any](m *Map[K, V]) *FrozenMap[K, V] {
+	c := m.Copy()
+	if n := capacityFor(c.size, c.maxLoadFactor()); c.entries != nil && n < len(c.entries) {
+		c.resize(n)
+	}
+	return &FrozenMap[K, V]{c}
+}
+
+// Size returns the number of elements in the map.
+func (f *FrozenMap[K, V]) Size() int {
+	return f.m.Size()
+}
+
+// Get returns the value associated with the given key and whether the key was found.
+func (f *FrozenMap[K, V]) Get(key K) (V, bool) {
+	return f.m.Get(key)
+}
+
+// Contains returns true if the map contains the given key.
+func (f *FrozenMap[K, V]) Contains(key K) bool {
+	_, ok := f.m.Get(key)
+	return ok
+}
+
+// ForEach calls the given function for each key/value pair in the map, as Map.ForEach does.
+func (f *FrozenMap[K, V]) ForEach(fn func(K, V) error) error {
+	return f.m.ForEach(fn)
+}
+
+// Hash returns the hash code for the map. It equals the hash of the map it was frozen from.
+func (f *FrozenMap[K, V]) Hash() uint64 {
+	return f.m.Hash()
+}
+
+// Equals returns true if the map is equal to the given map, as Map.Equals defines it.
+func (f *FrozenMap[K, V]) Equals(other *FrozenMap[K, V]) bool {
+	return f.m.Equals(other.m)
+}
@@ -0,0 +1,57 @@
+package hashmap
+
+import "testing"
+
+func TestFrozenSet(t *testing.T) {
+	s := NewSet[Int](1000)
+	for i := 0; i < 100; i++ {
+		s.Add(Int(i))
+	}
+	f := Freeze(s)
+	s.Add(100)
+	if f.Size() != 100 || f.Contains(100) || !f.Contains(42) {
+		t.Errorf("expected the frozen set to be unaffected by later changes")
+	}
+	if len(f.s.entries) != capacityFor(100, defaultMaxLoadFactor) {
+		t.Errorf("expected a compact backing array, got %d slots", len(f.s.entries))
+	}
+	s.Remove(100)
+	if f.Hash() != s.Hash() || !f.Equals(Freeze(s)) {
+		t.Errorf("expected frozen set to hash and compare like its source")
+	}
+	n := 0
+	f.ForEach(func(k Int) error { n++; return nil })
+	if n != 100 {
+		t.Errorf("expected 100 elements, got %d", n)
+	}
+	if empty := Freeze(&Set[Int]{}); empty.Size() != 0 || empty.Contains(1) {
+		t.Errorf("expected empty frozen set")
+	}
+}
+
+func TestFrozenMap(t *testing.T) {
+	m := NewMap[Int, Int](1000)
+	for i := 0; i < 100; i++ {
+		m.Put(Int(i), Int(i*2))
+	}
+	f := FreezeMap(m)
+	m.Put(1, 1)
+	if v, ok := f.Get(1); !ok || v != 2 || f.Contains(100) || f.Size() != 100 {
+		t.Errorf("expected the frozen map to be unaffected by later changes")
+	}
+	if len(f.m.entries) >= len(m.entries) {
+		t.Errorf("expected a compact backing array, got %d slots", len(f.m.entries))
+	}
+	m.Put(1, 2)
+	if f.Hash() != m.Hash() || !f.Equals(FreezeMap(m)) {
+		t.Errorf("expected frozen map to hash and compare like its source")
+	}
+	sum := Int(0)
+	f.ForEach(func(k, v Int) error { sum += v; return nil })
+	if sum != 9900 {
+		t.Errorf("expected sum 9900, got %d", sum)
+	}
+	if empty := FreezeMap(&Map[Int, Int]{}); empty.Size() != 0 || empty.Contains(1) {
+		t.Errorf("expected empty frozen map")
+	}
+}
@@ -0,0 +1,52 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode implements gob.GobEncoder.
+// The map is encoded as a slice of entries.
+func (m *Map[K, V]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m.Entries()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+// It decodes data produced by GobEncode and adds the entries to the map.
+func (m *Map[K, V]) GobDecode(data []byte) error {
+	var entries []Entry[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		m.Put(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+// The set is encoded as a slice of keys.
+func (s *Set[K]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.ToSlice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+// It decodes data produced by GobEncode and adds the keys to the set.
+func (s *Set[K]) GobDecode(data []byte) error {
+	var keys []K
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&keys); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		s.Add(key)
+	}
+	return nil
+}
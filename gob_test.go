@@ -0,0 +1,33 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestGob(t *testing.T) {
+	type record struct {
+		Map *Map[String, Float64]
+		Set *Set[Int]
+	}
+	m := &Map[String, Float64]{}
+	m.Put("pi", 3.14)
+	m.Put("e", 2.72)
+	in := record{m, intSet(1, 2, 3)}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Map.Equals(in.Map) {
+		t.Errorf("expected round trip to preserve the map")
+	}
+	if !out.Set.Equals(in.Set) {
+		t.Errorf("expected round trip to preserve the set")
+	}
+}
@@ -0,0 +1,51 @@
+package hashmap
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+)
+
+// HashBuilder computes the hash of a key made of several fields,
+// for use in Hash methods of custom Comparable types.
+// It uses the same seed as the element types of this package.
+// Strings and byte slices are written with their length,
+// so that fields "ab", "c" and "a", "bc" hash differently.
+type HashBuilder struct {
+	h maphash.Hash
+}
+
+// NewHashBuilder returns a HashBuilder seeded with the package seed.
+func NewHashBuilder() HashBuilder {
+	var b HashBuilder
+	b.h.SetSeed(seed)
+	return b
+}
+
+// WriteUint64 adds v to the hash.
+func (b *HashBuilder) WriteUint64(v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	b.h.Write(buf[:])
+}
+
+// WriteString adds the length and bytes of v to the hash.
+func (b *HashBuilder) WriteString(v string) {
+	b.WriteUint64(uint64(len(v)))
+	b.h.WriteString(v)
+}
+
+// WriteBytes adds the length and contents of v to the hash.
+func (b *HashBuilder) WriteBytes(v []byte) {
+	b.WriteUint64(uint64(len(v)))
+	b.h.Write(v)
+}
+
+// WriteHash adds a hash returned by the Hash method of a field to the hash.
+func (b *HashBuilder) WriteHash(v uint64) {
+	b.WriteUint64(v)
+}
+
+// Sum64 returns the hash of everything written so far.
+func (b *HashBuilder) Sum64() uint64 {
+	return b.h.Sum64()
+}
@@ -0,0 +1,27 @@
+package hashmap
+
+import "testing"
+
+func TestHashBuilder(t *testing.T) {
+	sum := func(write func(*HashBuilder)) uint64 {
+		b := NewHashBuilder()
+		write(&b)
+		return b.Sum64()
+	}
+	ab := sum(func(b *HashBuilder) { b.WriteString("ab"); b.WriteString("c") })
+	if ab != sum(func(b *HashBuilder) { b.WriteString("ab"); b.WriteString("c") }) {
+		t.Errorf("expected equal fields to hash equally")
+	}
+	if ab == sum(func(b *HashBuilder) { b.WriteString("a"); b.WriteString("bc") }) {
+		t.Errorf("expected field boundaries to affect the hash")
+	}
+	if ab == sum(func(b *HashBuilder) { b.WriteBytes([]byte("a")); b.WriteBytes([]byte("bc")) }) {
+		t.Errorf("expected field boundaries to affect the hash of byte slices")
+	}
+	if sum(func(b *HashBuilder) { b.WriteUint64(1) }) == sum(func(b *HashBuilder) { b.WriteUint64(1 << 56) }) {
+		t.Errorf("expected WriteUint64 to use all bytes")
+	}
+	if sum(func(b *HashBuilder) { b.WriteHash(Int(1).Hash()) }) == sum(func(b *HashBuilder) { b.WriteHash(Int(2).Hash()) }) {
+		t.Errorf("expected different field hashes to give different hashes")
+	}
+}
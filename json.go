@@ -0,0 +1,168 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MarshalJSON implements json.Marshaler.
+// If K implements encoding.TextMarshaler, as String does, the map is encoded as a JSON object
+// with keys in sorted order. Otherwise it is encoded as a JSON array of [key, value] pairs.
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	var zero K
+	if _, ok := any(zero).(encoding.TextMarshaler); ok {
+		return m.marshalJSONObject()
+	}
+	return m.marshalJSONPairs()
+}
+
+func (m *Map[K, V]) marshalJSONObject() ([]byte, error) {
+	type field struct {
+		key   string
+		value V
+	}
+	fields := make([]field, 0, m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			text, err := any(entry.key).(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{string(text), entry.value})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (m *Map[K, V]) marshalJSONPairs() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	first := true
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			k, err := json.Marshal(entry.key)
+			if err != nil {
+				return nil, err
+			}
+			v, err := json.Marshal(entry.value)
+			if err != nil {
+				return nil, err
+			}
+			buf.WriteByte('[')
+			buf.Write(k)
+			buf.WriteByte(',')
+			buf.Write(v)
+			buf.WriteByte(']')
+		}
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts both forms produced by MarshalJSON and adds the decoded entries to the map.
+// Decoding a JSON object requires *K to implement encoding.TextUnmarshaler.
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		return m.unmarshalJSONObject(data)
+	case len(data) > 0 && data[0] == '[':
+		return m.unmarshalJSONPairs(data)
+	}
+	return errors.New("hashmap: expected JSON object or array")
+}
+
+func (m *Map[K, V]) unmarshalJSONObject(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for text, raw := range fields {
+		var key K
+		u, ok := any(&key).(encoding.TextUnmarshaler)
+		if !ok {
+			return fmt.Errorf("hashmap: key type %T does not implement encoding.TextUnmarshaler", key)
+		}
+		if err := u.UnmarshalText([]byte(text)); err != nil {
+			return err
+		}
+		var value V
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		m.Put(key, value)
+	}
+	return nil
+}
+
+func (m *Map[K, V]) unmarshalJSONPairs(data []byte) error {
+	var pairs [][]json.RawMessage
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			return errors.New("hashmap: expected [key, value] pair")
+		}
+		var key K
+		if err := json.Unmarshal(pair[0], &key); err != nil {
+			return err
+		}
+		var value V
+		if err := json.Unmarshal(pair[1], &value); err != nil {
+			return err
+		}
+		m.Put(key, value)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The set is encoded as a JSON array of its elements in unspecified order.
+func (s *Set[K]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It decodes a JSON array and adds its elements to the set.
+func (s *Set[K]) UnmarshalJSON(data []byte) error {
+	var keys []K
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		s.Add(key)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package hashmap
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMapJSON(t *testing.T) {
+	m := Map[String, Int]{}
+	m.Put("b", 2)
+	m.Put("a", 1)
+	data, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a":1,"b":2}` {
+		t.Errorf("expected JSON object, got %s", data)
+	}
+	var r Map[String, Int]
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equals(&m) {
+		t.Errorf("expected round trip to preserve the map")
+	}
+
+	n := intMap(1, 10, 2, 20)
+	data, err = json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[[1,10],[2,20]]` && string(data) != `[[2,20],[1,10]]` {
+		t.Errorf("expected JSON array of pairs, got %s", data)
+	}
+	var s Map[Int, Int]
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equals(n) {
+		t.Errorf("expected round trip to preserve the map")
+	}
+
+	if data, _ := json.Marshal(&Map[String, Int]{}); string(data) != `{}` {
+		t.Errorf("expected empty JSON object, got %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"1":10}`), &s); err == nil {
+		t.Errorf("expected error decoding object into map without text keys")
+	}
+	if err := json.Unmarshal([]byte(`[[1]]`), &s); err == nil {
+		t.Errorf("expected error decoding malformed pair")
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	ints := intSet(1, 2, 3)
+	data, err := json.Marshal(ints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []int
+	if err := json.Unmarshal(data, &keys); err != nil || len(keys) != 3 {
+		t.Errorf("expected JSON array of 3 numbers, got %s", data)
+	}
+	var r Set[Int]
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equals(ints) {
+		t.Errorf("expected round trip to preserve the set")
+	}
+
+	strs := Set[String]{}
+	strs.Add("a")
+	strs.Add("b")
+	data, err = json.Marshal(&strs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rs Set[String]
+	if err := json.Unmarshal(data, &rs); err != nil {
+		t.Fatal(err)
+	}
+	if !rs.Equals(&strs) {
+		t.Errorf("expected round trip to preserve the set")
+	}
+
+	floats := Set[Float64]{}
+	floats.Add(1.5)
+	data, err = json.Marshal(&floats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[1.5]` {
+		t.Errorf("expected [1.5], got %s", data)
+	}
+	var rf Set[Float64]
+	if err := json.Unmarshal(data, &rf); err != nil {
+		t.Fatal(err)
+	}
+	if !rf.Equals(&floats) {
+		t.Errorf("expected round trip to preserve the set")
+	}
+
+	if data, _ := json.Marshal(&Set[Int]{}); string(data) != `[]` {
+		t.Errorf("expected empty JSON array, got %s", data)
+	}
+}
+
+// sortedJSONArray returns the JSON array data with its elements sorted as text,
+// so that sets can be compared with an expected encoding.
+func sortedJSONArray(t *testing.T, data []byte) string {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		t.Fatalf("expected a JSON array, got %s", data)
+	}
+	texts := make([]string, len(elems))
+	for i, e := range elems {
+		texts[i] = string(e)
+	}
+	slices.Sort(texts)
+	return "[" + strings.Join(texts, ",") + "]"
+}
+
+func TestSetJSONNumbers(t *testing.T) {
+	tests := []struct {
+		set      json.Marshaler
+		expected string
+	}{
+		{intSet(1, 2, 3), `[1,2,3]`},
+		{SetFromSlice([]Float64{1, 2.5, 3}), `[1,2.5,3]`},
+		{SetFromSlice([]Int64{-1, 2, 3}), `[-1,2,3]`},
+		{SetFromSlice([]Uint8{1, 2, 3}), `[1,2,3]`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.set)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sortedJSONArray(t, data); got != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, data)
+		}
+	}
+	var bytes Set[Byte]
+	if err := json.Unmarshal([]byte(`[1,2,3]`), &bytes); err != nil || !bytes.Equals(SetFromSlice([]Byte{1, 2, 3})) {
+		t.Errorf("expected a Set[Byte] to decode from an array of numbers, got %v and %v", &bytes, err)
+	}
+}
@@ -0,0 +1,122 @@
+package hashmap
+
+type lruNode[K Comparable[K], V any] struct {
+	key        K
+	value      V
+	prev, next *lruNode[K, V]
+}
+
+// LRU is a fixed-capacity cache that evicts the least recently used entry when full.
+// It is not thread-safe.
+// An LRU must be created with NewLRU.
+type LRU[K Comparable[K], V any] struct {
+	capacity    int
+	nodes       Map[K, *lruNode[K, V]]
+	front, back *lruNode[K, V]
+	onEvict     func(K, V)
+}
+
+// LRUOption configures an LRU created by NewLRU.
+type LRUOption[K Comparable[K], V any] func(*LRU[K, V])
+
+// WithOnEvict sets a function that is called with each entry evicted to make room for a new one.
+func WithOnEvict[K Comparable[K], V any](f func(K, V)) LRUOption[K, V] {
+	return func(c *LRU[K, V]) {
+		c.onEvict = f
+	}
+}
+
+// NewLRU returns a new empty cache that holds at most capacity entries.
+// It panics if capacity is not positive.
+func NewLRU[K Comparable[K], V any](capacity int, opts ...LRUOption[K, V]) *LRU[K, V] {
+	if capacity <= 0 {
+		panic("hashmap: invalid LRU capacity")
+	}
+	c := &LRU[K, V]{capacity: capacity}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Size returns the number of entries in the cache.
+func (c *LRU[K, V]) Size() int {
+	return c.nodes.Size()
+}
+
+// Get returns the value associated with the given key and marks it as most recently used.
+// The second return value indicates if the key was found.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	n, ok := c.nodes.Get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.unlink(n)
+	c.pushFront(n)
+	return n.value, true
+}
+
+// Peek returns the value associated with the given key without changing its recency.
+// The second return value indicates if the key was found.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	if n, ok := c.nodes.Get(key); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put adds the given key/value pair to the cache and marks it as most recently used.
+// If the key already exists, the value is updated.
+// If the cache is full, the least recently used entry is evicted first.
+func (c *LRU[K, V]) Put(key K, value V) {
+	if n, ok := c.nodes.Get(key); ok {
+		n.value = value
+		c.unlink(n)
+		c.pushFront(n)
+		return
+	}
+	if c.nodes.Size() >= c.capacity {
+		evicted := c.back
+		c.unlink(evicted)
+		c.nodes.Remove(evicted.key)
+		if c.onEvict != nil {
+			c.onEvict(evicted.key, evicted.value)
+		}
+	}
+	n := &lruNode[K, V]{key: key, value: value}
+	c.nodes.Put(key, n)
+	c.pushFront(n)
+}
+
+// Remove removes the given key from the cache.
+func (c *LRU[K, V]) Remove(key K) {
+	if n, ok := c.nodes.GetAndRemove(key); ok {
+		c.unlink(n)
+	}
+}
+
+func (c *LRU[K, V]) unlink(n *lruNode[K, V]) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		c.front = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		c.back = n.prev
+	}
+	n.prev, n.next = nil, nil
+}
+
+func (c *LRU[K, V]) pushFront(n *lruNode[K, V]) {
+	n.next = c.front
+	if c.front != nil {
+		c.front.prev = n
+	} else {
+		c.back = n
+	}
+	c.front = n
+}
@@ -0,0 +1,51 @@
+package hashmap
+
+import "testing"
+
+func TestLRU(t *testing.T) {
+	var evicted []Int
+	c := NewLRU(3, WithOnEvict(func(k Int, v Int) {
+		evicted = append(evicted, k)
+	}))
+	c.Put(1, 10)
+	c.Put(2, 20)
+	c.Put(3, 30)
+	if _, ok := c.Get(1); !ok {
+		t.Errorf("expected to find key 1")
+	}
+	c.Put(4, 40)
+	if _, ok := c.Peek(2); ok {
+		t.Errorf("expected least recently used key 2 to be evicted")
+	}
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Errorf("expected OnEvict to be called with key 2, got %v", evicted)
+	}
+
+	if v, ok := c.Peek(3); !ok || v != 30 {
+		t.Errorf("expected value 30, got %d", v)
+	}
+	c.Put(5, 50)
+	if _, ok := c.Peek(3); ok {
+		t.Errorf("expected Peek to not update recency of key 3")
+	}
+
+	c.Put(1, 11)
+	c.Put(6, 60)
+	if v, ok := c.Get(1); !ok || v != 11 {
+		t.Errorf("expected updated key 1 to be kept with value 11, got %d", v)
+	}
+	if _, ok := c.Peek(4); ok {
+		t.Errorf("expected key 4 to be evicted")
+	}
+
+	c.Remove(1)
+	c.Remove(100)
+	if c.Size() != 2 {
+		t.Errorf("expected size 2, got %d", c.Size())
+	}
+	c.Put(7, 70)
+	c.Put(8, 80)
+	if c.Size() != 3 {
+		t.Errorf("expected size 3, got %d", c.Size())
+	}
+}
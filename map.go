@@ -1,20 +1,169 @@
 package hashmap
 
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"slices"
+	"sync"
+)
+
 const fullBit = 1 << 63
 const initialCapacity = 16
 
+// ErrStopIteration can be returned from a ForEach callback to stop iteration early.
+// ForEach then returns nil rather than the error.
+var ErrStopIteration = errors.New("stop iteration")
+
+const defaultMaxLoadFactor = 0.75
+const defaultMinLoadFactor = 0.25
+
 type mapEntry[K Comparable[K], V any] struct {
 	hash1 uint64
 	key   K
 	value V
 }
 
-// Map is a hash map that uses open addressing with linear probing.
+// Map is a hash map that uses open addressing with linear probing,
+// optionally with Robin Hood hashing (see WithRobinHoodProbing).
 // It is not thread-safe.
 // The zero value is an empty map ready to use.
+// Map implements Comparable, so it can be used as a key in a Map or an element in a Set.
 type Map[K Comparable[K], V any] struct {
-	entries []mapEntry[K, V]
-	size    int
+	entries   []mapEntry[K, V]
+	size      int
+	maxLoad   float64
+	minLoad   float64
+	robinHood bool
+	salt      uint64
+	popIndex  int // where Pop resumes its search for an entry
+}
+
+// MapOption configures a Map created by NewMap.
+type MapOption[K Comparable[K], V any] func(*Map[K, V])
+
+// WithLoadFactor sets the load factors at which the map grows and shrinks.
+// The map grows when more than max of the backing array is occupied
+// and shrinks when less than min of it is occupied.
+// It panics unless 0 < min < max < 1.
+func WithLoadFactor[K Comparable[K], V any](max, min float64) MapOption[K, V] {
+	if !(0 < min && min < max && max < 1) {
+		panic("hashmap: invalid load factors")
+	}
+	return func(m *Map[K, V]) {
+		m.maxLoad = max
+		m.minLoad = min
+	}
+}
+
+// WithRobinHoodProbing makes the map use Robin Hood hashing.
+// On insertion, an entry that is further from its home slot than the entry
+// occupying a slot takes that slot, and the displaced entry moves on.
+// This evens out probe lengths, which helps most at high load factors.
+func WithRobinHoodProbing[K Comparable[K], V any]() MapOption[K, V] {
+	return func(m *Map[K, V]) {
+		m.robinHood = true
+	}
+}
+
+// WithSeed makes the map derive the home slots of its keys from the given seed
+// rather than from the key hashes alone. Maps with different seeds place the same
+// keys differently, so inputs crafted to collide in one map do not collide in another.
+// Key hashes themselves, and therefore Hash and Equals, are not affected.
+//
+// The seed only protects against keys whose hashes differ but share a home slot.
+// Keys whose Hash methods return the same value collide under every seed.
+// The element types of this package hash with a random per-process seed,
+// so such keys cannot be crafted for them, but a custom Hash method must make
+// full collisions hard to find itself, for example by using HashBuilder.
+func WithSeed[K Comparable[K], V any](s maphash.Seed) MapOption[K, V] {
+	return func(m *Map[K, V]) {
+		m.salt = saltFor(s)
+	}
+}
+
+// WithRandomSeed is like WithSeed with a new random seed for each map.
+func WithRandomSeed[K Comparable[K], V any]() MapOption[K, V] {
+	return func(m *Map[K, V]) {
+		m.salt = saltFor(maphash.MakeSeed())
+	}
+}
+
+// NewMap returns a new map with room for at least capacity elements without resizing.
+// If capacity is not positive, the map starts out empty like the zero value.
+func NewMap[K Comparable[K], V any](capacity int, opts ...MapOption[K, V]) *Map[K, V] {
+	m := &Map[K, V]{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if capacity > 0 {
+		m.entries = make([]mapEntry[K, V], capacityFor(capacity, m.maxLoadFactor()))
+	}
+	return m
+}
+
+// capacityFor returns the smallest backing array length that holds n elements
+// at the given load factor without resizing.
+func capacityFor(n int, maxLoad float64) int {
+	c := initialCapacity
+	for float64(n) > maxLoad*float64(c) {
+		c *= 2
+	}
+	return c
+}
+
+func (m *Map[K, V]) maxLoadFactor() float64 {
+	if m.maxLoad == 0 {
+		return defaultMaxLoadFactor
+	}
+	return m.maxLoad
+}
+
+func (m *Map[K, V]) minLoadFactor() float64 {
+	if m.minLoad == 0 {
+		return defaultMinLoadFactor
+	}
+	return m.minLoad
+}
+
+// shouldGrow returns true if the map is above its maximum load factor.
+func (m *Map[K, V]) shouldGrow() bool {
+	return float64(m.size) > m.maxLoadFactor()*float64(len(m.entries))
+}
+
+// shouldShrink returns true if the map is below its minimum load factor
+// and halving the backing array would not put it above the maximum load factor.
+func (m *Map[K, V]) shouldShrink() bool {
+	return m.canShrink(len(m.entries))
+}
+
+// canShrink reports whether a backing array of length c should be halved
+// for the current size.
+func (m *Map[K, V]) canShrink(c int) bool {
+	if c <= initialCapacity {
+		return false
+	}
+	n := float64(m.size)
+	return n < m.minLoadFactor()*float64(c) && n <= m.maxLoadFactor()*float64(c/2)
+}
+
+// newLike returns an empty map with the same options as m
+// and room for capacity elements without resizing.
+func (m *Map[K, V]) newLike(capacity int) *Map[K, V] {
+	r := &Map[K, V]{maxLoad: m.maxLoad, minLoad: m.minLoad, robinHood: m.robinHood, salt: m.salt}
+	if capacity > 0 {
+		r.entries = make([]mapEntry[K, V], capacityFor(capacity, r.maxLoadFactor()))
+	}
+	return r
+}
+
+// home returns the index of the slot where probing for hash1 starts.
+func (m *Map[K, V]) home(hash1 uint64) uint64 {
+	if m.salt != 0 {
+		hash1 = salted(hash1, m.salt)
+	}
+	return hash1 & uint64(len(m.entries)-1)
 }
 
 func (m *Map[K, V]) init() {
@@ -26,6 +175,120 @@ func (m *Map[K, V]) Size() int {
 	return m.size
 }
 
+// Capacity returns the length of the backing array of the map.
+func (m *Map[K, V]) Capacity() int {
+	return len(m.entries)
+}
+
+// LoadFactor returns the fraction of the backing array that is occupied.
+// It returns 0 for a map without a backing array.
+func (m *Map[K, V]) LoadFactor() float64 {
+	if len(m.entries) == 0 {
+		return 0
+	}
+	return float64(m.size) / float64(len(m.entries))
+}
+
+// MaxProbeLength returns the length of the longest run of consecutive occupied slots
+// in the backing array, wrapping around at the end.
+func (m *Map[K, V]) MaxProbeLength() int {
+	if m.size == 0 {
+		return 0
+	}
+	n := len(m.entries)
+	start := 0
+	for m.entries[start].hash1 != 0 {
+		start++
+	}
+	longest, run := 0, 0
+	for i := 1; i <= n; i++ {
+		if m.entries[(start+i)%n].hash1 != 0 {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// MapStats describes the state of a map's backing array, as returned by Map.Stats.
+type MapStats struct {
+	Size       int
+	Capacity   int
+	LoadFactor float64
+	// MaxProbeLength is the length of the longest run of occupied slots, as from Map.MaxProbeLength.
+	MaxProbeLength int
+	// MeanProbeLength is the mean distance of the entries from their home slots.
+	// It is a float64 because at usual load factors it is below 1,
+	// which an int would truncate to 0.
+	MeanProbeLength float64
+	EmptySlots      int
+	// TombstoneSlots is always 0, because removal never leaves tombstones behind.
+	TombstoneSlots int
+}
+
+// Stats returns diagnostics about the map. It scans the whole backing array.
+func (m *Map[K, V]) Stats() MapStats {
+	stats := MapStats{
+		Size:           m.size,
+		Capacity:       len(m.entries),
+		LoadFactor:     m.LoadFactor(),
+		MaxProbeLength: m.MaxProbeLength(),
+		EmptySlots:     len(m.entries) - m.size,
+	}
+	if m.size == 0 {
+		return stats
+	}
+	mask := uint64(len(m.entries) - 1)
+	total := 0
+	for i, entry := range m.entries {
+		if entry.hash1 != 0 {
+			total += int((uint64(i) - m.home(entry.hash1)) & mask)
+		}
+	}
+	stats.MeanProbeLength = float64(total) / float64(m.size)
+	return stats
+}
+
+// IsEmpty returns true if the map has no elements.
+func (m *Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear removes all elements from the map.
+// The backing array is reused if it is already at the initial capacity.
+func (m *Map[K, V]) Clear() {
+	if m.entries == nil {
+		return
+	}
+	if len(m.entries) == initialCapacity {
+		for i := range m.entries {
+			m.entries[i] = mapEntry[K, V]{}
+		}
+	} else {
+		m.init()
+	}
+	m.size = 0
+}
+
+// Drain removes all elements from the map and returns them in unspecified order.
+// The backing array is handed off rather than cleared,
+// so the map is left without one, like the zero value.
+func (m *Map[K, V]) Drain() []Entry[K, V] {
+	entries, size := m.entries, m.size
+	m.entries, m.size = nil, 0
+	r := make([]Entry[K, V], 0, size)
+	for _, entry := range entries {
+		if entry.hash1 != 0 {
+			r = append(r, Entry[K, V]{entry.key, entry.value})
+		}
+	}
+	return r
+}
+
 // Get returns the value associated with the given key.
 // The second return value indicates if the key was found.
 // The value is the zero value for the value type if the key was not found.
@@ -35,7 +298,7 @@ func (m *Map[K, V]) Get(key K) (V, bool) {
 		return zero, false
 	}
 	hash1 := key.Hash() | fullBit
-	index := hash1 & uint64(len(m.entries)-1)
+	index := m.home(hash1)
 	entry := m.entries[index]
 	for entry.hash1 != 0 {
 		if entry.hash1 == hash1 && entry.key.Equals(key) {
@@ -47,6 +310,125 @@ func (m *Map[K, V]) Get(key K) (V, bool) {
 	return zero, false
 }
 
+// MustGet returns the value associated with the given key.
+// It panics if the key is not in the map, so it should only be used
+// where a missing key is a programming error.
+func (m *Map[K, V]) MustGet(key K) V {
+	v, ok := m.Get(key)
+	if !ok {
+		panic(fmt.Sprintf("hashmap: key %v not found", key))
+	}
+	return v
+}
+
+// ContainsValue returns true if eq returns true for any value in the map and val.
+// It scans the whole map in the worst case.
+func (m *Map[K, V]) ContainsValue(val V, eq func(V, V) bool) bool {
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && eq(entry.value, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPointer returns a pointer to the value associated with the given key,
+// or nil if the key was not found. The value can be modified through the pointer.
+// The pointer is invalidated by any call that modifies the map, such as Put or Remove,
+// and must not be kept beyond its immediate use.
+func (m *Map[K, V]) GetPointer(key K) *V {
+	if m.size == 0 {
+		return nil
+	}
+	index, ok := m.findHash1(key.Hash()|fullBit, key)
+	if !ok {
+		return nil
+	}
+	return &m.entries[index].value
+}
+
+// GetOrInsertZero returns a pointer to the value associated with the given key.
+// If the key is not in the map, it is inserted with the zero value first,
+// so that *m.GetOrInsertZero(k) += 1 counts occurrences of k.
+// The pointer is invalidated by any call that modifies the map, as with GetPointer.
+func (m *Map[K, V]) GetOrInsertZero(key K) *V {
+	var zero V
+	return m.GetOrInsert(key, zero)
+}
+
+// GetOrInsert returns a pointer to the value associated with the given key.
+// If the key is not in the map, it is inserted with the given value first.
+// The pointer is invalidated by any call that modifies the map, as with GetPointer.
+func (m *Map[K, V]) GetOrInsert(key K, value V) *V {
+	if m.entries == nil {
+		m.init()
+	}
+	hash1 := key.Hash() | fullBit
+	index, ok := m.findHash1(hash1, key)
+	if !ok {
+		capacity := len(m.entries)
+		m.insertAt(index, hash1, key, value)
+		if len(m.entries) != capacity {
+			index, _ = m.findHash1(hash1, key)
+		}
+	}
+	return &m.entries[index].value
+}
+
+// BatchGet returns the values associated with the given keys
+// and whether each key was found, in the same order as keys.
+// All keys are hashed before any probing starts, which keeps the hashing
+// and the memory accesses of the probes in separate tight loops.
+func (m *Map[K, V]) BatchGet(keys []K) ([]V, []bool) {
+	values := make([]V, len(keys))
+	found := make([]bool, len(keys))
+	if m.size == 0 {
+		return values, found
+	}
+	hashes := make([]uint64, len(keys))
+	for i, key := range keys {
+		hashes[i] = key.Hash() | fullBit
+	}
+	for i, key := range keys {
+		if index, ok := m.findHash1(hashes[i], key); ok {
+			values[i] = m.entries[index].value
+			found[i] = true
+		}
+	}
+	return values, found
+}
+
+// BatchPut stores all the given entries in the map, growing it at most once.
+// It is the same as PutAll and pairs with BatchGet.
+func (m *Map[K, V]) BatchPut(entries []Entry[K, V]) {
+	m.PutAll(entries)
+}
+
+// BatchRemove removes the given keys from the map, shrinking it at most once.
+// It is the same as RemoveAll and pairs with BatchGet.
+func (m *Map[K, V]) BatchRemove(keys []K) {
+	m.RemoveAll(keys)
+}
+
+// GetOrDefault returns the value associated with the given key,
+// or def if the key was not found.
+func (m *Map[K, V]) GetOrDefault(key K, def V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return def
+}
+
+// GetOrElse returns the value associated with the given key.
+// If the key was not found, it returns the result of calling f.
+// f is only called when the key is missing.
+func (m *Map[K, V]) GetOrElse(key K, f func() V) V {
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	return f()
+}
+
 // Put adds the given key/value pair to the map.
 // If the key already exists, the value is updated.
 func (m *Map[K, V]) Put(key K, value V) {
@@ -57,27 +439,235 @@ func (m *Map[K, V]) Put(key K, value V) {
 	m.putHash1(hash1, key, value)
 }
 
+// PutReturningOld associates the given value with the given key.
+// It returns the previous value and true if the key was already in the map,
+// or the zero value and false if it was not.
+func (m *Map[K, V]) PutReturningOld(key K, value V) (V, bool) {
+	if m.entries == nil {
+		m.init()
+	}
+	hash1 := key.Hash() | fullBit
+	index, ok := m.findHash1(hash1, key)
+	if ok {
+		old := m.entries[index].value
+		m.entries[index].value = value
+		return old, true
+	}
+	m.insertAt(index, hash1, key, value)
+	var zero V
+	return zero, false
+}
+
 func (m *Map[K, V]) putHash1(hash1 uint64, key K, value V) {
-	index := hash1 & uint64(len(m.entries)-1)
+	index, ok := m.findHash1(hash1, key)
+	if ok {
+		m.entries[index].value = value
+		return
+	}
+	m.insertAt(index, hash1, key, value)
+}
+
+// findHash1 returns the index of the entry with the given key.
+// If the key is not in the map, it returns the index of the slot
+// where the key would be inserted and false. That slot is empty,
+// except with Robin Hood probing, where insertAt displaces its entry.
+func (m *Map[K, V]) findHash1(hash1 uint64, key K) (uint64, bool) {
+	mask := uint64(len(m.entries) - 1)
+	index := m.home(hash1)
 	entry := m.entries[index]
-	for entry.hash1 != 0 {
+	for dist := uint64(0); entry.hash1 != 0; dist++ {
 		if entry.hash1 == hash1 && entry.key.Equals(key) {
-			entry.value = value
-			return
+			return index, true
 		}
-		index = (index + 1) & uint64(len(m.entries)-1)
+		if m.robinHood && (index-m.home(entry.hash1))&mask < dist {
+			// The key would have displaced this entry, so it is not further along.
+			return index, false
+		}
+		index = (index + 1) & mask
 		entry = m.entries[index]
 	}
-	m.entries[index] = mapEntry[K, V]{hash1, key, value}
+	return index, false
+}
+
+// insertAt stores a new entry in the slot at the given index returned by findHash1
+// and grows the map if needed.
+func (m *Map[K, V]) insertAt(index uint64, hash1 uint64, key K, value V) {
+	entry := mapEntry[K, V]{hash1, key, value}
+	if m.robinHood {
+		m.displace(index, entry)
+	} else {
+		m.entries[index] = entry
+	}
 	m.size++
-	if m.size > 3*len(m.entries)/4 {
+	if m.shouldGrow() {
 		m.resize(len(m.entries) * 2)
 	}
 }
 
+// displace stores entry at the given index for Robin Hood probing.
+// Whenever the entry being placed is further from its home slot than the occupant,
+// they swap and the occupant moves on, until an empty slot is reached.
+func (m *Map[K, V]) displace(index uint64, entry mapEntry[K, V]) {
+	mask := uint64(len(m.entries) - 1)
+	for m.entries[index].hash1 != 0 {
+		if (index-m.home(m.entries[index].hash1))&mask < (index-m.home(entry.hash1))&mask {
+			entry, m.entries[index] = m.entries[index], entry
+		}
+		index = (index + 1) & mask
+	}
+	m.entries[index] = entry
+}
+
+// ComputeIfAbsent returns the value associated with the given key.
+// If the key is not in the map, f is called to compute the value,
+// which is then stored in the map and returned.
+// f must not modify the map.
+func (m *Map[K, V]) ComputeIfAbsent(key K, f func(K) V) V {
+	if m.entries == nil {
+		m.init()
+	}
+	hash1 := key.Hash() | fullBit
+	index, ok := m.findHash1(hash1, key)
+	if ok {
+		return m.entries[index].value
+	}
+	size := m.size
+	value := f(key)
+	if m.size != size {
+		panic("hashmap: map modified during ComputeIfAbsent")
+	}
+	m.insertAt(index, hash1, key, value)
+	return value
+}
+
+// ComputeIfPresent replaces the value associated with the given key
+// with the result of calling f on the key and the current value.
+// It returns the new value and true if the key was found.
+// If the key was not found, f is not called and the zero value and false are returned.
+// f must not modify the map.
+func (m *Map[K, V]) ComputeIfPresent(key K, f func(K, V) V) (V, bool) {
+	var zero V
+	if m.size == 0 {
+		return zero, false
+	}
+	hash1 := key.Hash() | fullBit
+	index, ok := m.findHash1(hash1, key)
+	if !ok {
+		return zero, false
+	}
+	size := m.size
+	value := f(key, m.entries[index].value)
+	if m.size != size {
+		panic("hashmap: map modified during ComputeIfPresent")
+	}
+	m.entries[index].value = value
+	return value, true
+}
+
+// Compute stores the result of calling f as the value for the given key and returns it.
+// f is called with the key, the current value and true if the key was found,
+// or with the key, the zero value and false if it was not.
+// f must not modify the map.
+func (m *Map[K, V]) Compute(key K, f func(K, V, bool) V) V {
+	if m.entries == nil {
+		m.init()
+	}
+	hash1 := key.Hash() | fullBit
+	index, ok := m.findHash1(hash1, key)
+	var current V
+	if ok {
+		current = m.entries[index].value
+	}
+	size := m.size
+	value := f(key, current, ok)
+	if m.size != size {
+		panic("hashmap: map modified during Compute")
+	}
+	if ok {
+		m.entries[index].value = value
+	} else {
+		m.insertAt(index, hash1, key, value)
+	}
+	return value
+}
+
+// Upsert stores a value for the given key and returns it.
+// If the key is not in the map, the value is the result of calling insert with the key.
+// Otherwise it is the result of calling update with the key and the current value.
+// insert and update must not modify the map.
+func (m *Map[K, V]) Upsert(key K, insert func(K) V, update func(K, V) V) V {
+	if m.entries == nil {
+		m.init()
+	}
+	hash1 := key.Hash() | fullBit
+	index, ok := m.findHash1(hash1, key)
+	size := m.size
+	var value V
+	if ok {
+		value = update(key, m.entries[index].value)
+	} else {
+		value = insert(key)
+	}
+	if m.size != size {
+		panic("hashmap: map modified during Upsert")
+	}
+	if ok {
+		m.entries[index].value = value
+	} else {
+		m.insertAt(index, hash1, key, value)
+	}
+	return value
+}
+
+// Swap exchanges the values associated with k1 and k2 and returns true.
+// If either key is not in the map, it returns false and leaves the map unchanged.
+func (m *Map[K, V]) Swap(k1, k2 K) bool {
+	if m.size == 0 {
+		return false
+	}
+	// Each key is found by its own probe. Unless the keys share a home slot,
+	// their probe sequences are unrelated, and one walk covering both would
+	// have to scan the table.
+	i, ok := m.findHash1(k1.Hash()|fullBit, k1)
+	if !ok {
+		return false
+	}
+	j, ok := m.findHash1(k2.Hash()|fullBit, k2)
+	if !ok {
+		return false
+	}
+	m.entries[i].value, m.entries[j].value = m.entries[j].value, m.entries[i].value
+	return true
+}
+
+// Rename moves the value associated with oldKey to newKey and returns true.
+// If newKey is already in the map, its value is overwritten.
+// If oldKey is not in the map, the map is unchanged and Rename returns false.
+// If oldKey equals newKey, the map is also unchanged.
+func (m *Map[K, V]) Rename(oldKey, newKey K) bool {
+	if m.size == 0 {
+		return false
+	}
+	index, ok := m.findHash1(oldKey.Hash()|fullBit, oldKey)
+	if !ok {
+		return false
+	}
+	if oldKey.Equals(newKey) {
+		return true
+	}
+	value := m.entries[index].value
+	m.deleteAt(index)
+	m.putHash1(newKey.Hash()|fullBit, newKey, value)
+	if m.shouldShrink() {
+		m.resize(len(m.entries) / 2)
+	}
+	return true
+}
+
 func (m *Map[K, V]) resize(cap int) {
 	entries := m.entries
 	m.size = 0
+	m.popIndex = 0
 	m.entries = make([]mapEntry[K, V], cap)
 	for _, entry := range entries {
 		if entry.hash1 != 0 {
@@ -86,42 +676,158 @@ func (m *Map[K, V]) resize(cap int) {
 	}
 }
 
+// Rehash gives the map a new random seed, as with WithRandomSeed,
+// and reinserts all entries accordingly. It can break up long probe sequences,
+// as reported by MaxProbeLength, built up by adversarial keys.
+func (m *Map[K, V]) Rehash() {
+	m.salt = saltFor(maphash.MakeSeed())
+	if m.entries != nil {
+		m.resize(len(m.entries))
+	}
+}
+
+// Reserve grows the map so that additional elements can be added without resizing.
+// It does nothing if the map already has room for them.
+func (m *Map[K, V]) Reserve(additional int) {
+	c := capacityFor(m.size+additional, m.maxLoadFactor())
+	if c <= len(m.entries) {
+		return
+	}
+	if m.entries == nil {
+		m.entries = make([]mapEntry[K, V], c)
+		return
+	}
+	m.resize(c)
+}
+
 // Remove removes the given key from the map.
 func (m *Map[K, V]) Remove(key K) {
 	if m.size == 0 {
 		return
 	}
 	hash1 := key.Hash() | fullBit
-	index := hash1 & uint64(len(m.entries)-1)
-	entry := m.entries[index]
-	for entry.hash1 != 0 {
-		if entry.hash1 == hash1 && entry.key.Equals(key) {
-			m.entries[index] = mapEntry[K, V]{}
-			m.size--
-			if len(m.entries) > initialCapacity && m.size < len(m.entries)/4 {
-				m.resize(len(m.entries) / 2)
-				return
-			}
-			index = (index + 1) & uint64(len(m.entries)-1)
-			for m.entries[index].hash1 != 0 {
-				entry := m.entries[index]
-				m.entries[index] = mapEntry[K, V]{}
-				m.size--
-				m.putHash1(entry.hash1, entry.key, entry.value)
-				index = (index + 1) & uint64(len(m.entries)-1)
-			}
-			return
+	if index, ok := m.findHash1(hash1, key); ok {
+		m.removeAt(index)
+	}
+}
+
+// GetAndRemove removes the given key from the map and returns its value.
+// The second return value indicates if the key was found.
+func (m *Map[K, V]) GetAndRemove(key K) (V, bool) {
+	var zero V
+	if m.size == 0 {
+		return zero, false
+	}
+	hash1 := key.Hash() | fullBit
+	index, ok := m.findHash1(hash1, key)
+	if !ok {
+		return zero, false
+	}
+	value := m.entries[index].value
+	m.removeAt(index)
+	return value, true
+}
+
+// RemoveReturningOld removes the given key from the map and returns its value.
+// The second return value indicates if the key was found.
+// It is the same as GetAndRemove and pairs with PutReturningOld.
+func (m *Map[K, V]) RemoveReturningOld(key K) (V, bool) {
+	return m.GetAndRemove(key)
+}
+
+// Pop removes an arbitrary key/value pair from the map and returns it.
+// The third return value is false if the map is empty.
+func (m *Map[K, V]) Pop() (K, V, bool) {
+	var zeroK K
+	var zeroV V
+	if m.size == 0 {
+		return zeroK, zeroV, false
+	}
+	// Resume at the slot of the last pop rather than at slot 0, so that draining
+	// the map does not rescan the slots emptied so far. Deletion may shift a
+	// later entry into that slot, so the search starts there, not after it.
+	mask := len(m.entries) - 1
+	for i := range m.entries {
+		index := (m.popIndex + i) & mask
+		if entry := m.entries[index]; entry.hash1 != 0 {
+			m.popIndex = index
+			m.removeAt(uint64(index))
+			return entry.key, entry.value, true
+		}
+	}
+	return zeroK, zeroV, false
+}
+
+// RemoveAll removes the given keys from the map.
+// The map is shrunk at most once, after all keys have been removed.
+func (m *Map[K, V]) RemoveAll(keys []K) {
+	if m.size == 0 {
+		return
+	}
+	for _, key := range keys {
+		if index, ok := m.findHash1(key.Hash()|fullBit, key); ok {
+			m.deleteAt(index)
+		}
+	}
+	m.shrinkToFit()
+}
+
+// shrinkToFit halves the backing array as many times as removeAt would have,
+// with a single resize.
+func (m *Map[K, V]) shrinkToFit() {
+	c := len(m.entries)
+	for m.canShrink(c) {
+		c /= 2
+	}
+	if c != len(m.entries) {
+		m.resize(c)
+	}
+}
+
+// removeAt removes the entry at the given index and shrinks the map if needed.
+func (m *Map[K, V]) removeAt(index uint64) {
+	m.deleteAt(index)
+	if m.shouldShrink() {
+		m.resize(len(m.entries) / 2)
+	}
+}
+
+// deleteAt removes the entry at the given index without shrinking the map
+// and reinserts the entries that follow it in the probe sequence.
+func (m *Map[K, V]) deleteAt(index uint64) {
+	m.entries[index] = mapEntry[K, V]{}
+	m.size--
+	if m.robinHood {
+		// Shift the following entries back by one until one is in its home slot.
+		mask := uint64(len(m.entries) - 1)
+		next := (index + 1) & mask
+		for m.entries[next].hash1 != 0 && m.home(m.entries[next].hash1) != next {
+			m.entries[index] = m.entries[next]
+			m.entries[next] = mapEntry[K, V]{}
+			index, next = next, (next+1)&mask
 		}
+		return
+	}
+	index = (index + 1) & uint64(len(m.entries)-1)
+	for m.entries[index].hash1 != 0 {
+		entry := m.entries[index]
+		m.entries[index] = mapEntry[K, V]{}
+		m.size--
+		m.putHash1(entry.hash1, entry.key, entry.value)
 		index = (index + 1) & uint64(len(m.entries)-1)
-		entry = m.entries[index]
 	}
 }
 
 // ForEach calls the given function for each key/value pair in the map.
+// If f returns an error, iteration stops and ForEach returns that error,
+// unless it is ErrStopIteration, in which case ForEach returns nil.
 func (m *Map[K, V]) ForEach(f func(K, V) error) error {
 	for _, entry := range m.entries {
 		if entry.hash1 != 0 {
 			if err := f(entry.key, entry.value); err != nil {
+				if err == ErrStopIteration {
+					return nil
+				}
 				return err
 			}
 		}
@@ -129,14 +835,789 @@ func (m *Map[K, V]) ForEach(f func(K, V) error) error {
 	return nil
 }
 
-// Copy returns a copy of the map.
-func (m *Map[K, V]) Copy() *Map[K, V] {
-	if m.size == 0 {
-		return &Map[K, V]{}
+// ForEachParallel is like ForEach, but splits the backing array into concurrency
+// non-overlapping parts and calls f from one goroutine per part.
+// f must be safe for concurrent use, and neither f nor the caller may modify
+// the map until ForEachParallel returns.
+// The first error returned by f stops the remaining goroutines and is returned,
+// unless it is ErrStopIteration, in which case ForEachParallel returns nil.
+func (m *Map[K, V]) ForEachParallel(concurrency int, f func(K, V) error) error {
+	if concurrency < 1 {
+		panic("hashmap: invalid concurrency")
 	}
-	c := &Map[K, V]{}
-	c.entries = make([]mapEntry[K, V], len(m.entries))
-	copy(c.entries, m.entries)
-	c.size = m.size
-	return c
+	concurrency = min(concurrency, max(len(m.entries), 1))
+	errc := make(chan error, 1)
+	done := make(chan struct{})
+	var once sync.Once
+	var wg sync.WaitGroup
+	chunk := (len(m.entries) + concurrency - 1) / concurrency
+	for lo := 0; lo < len(m.entries); lo += chunk {
+		part := m.entries[lo:min(lo+chunk, len(m.entries))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, entry := range part {
+				if entry.hash1 == 0 {
+					continue
+				}
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if err := f(entry.key, entry.value); err != nil {
+					once.Do(func() {
+						errc <- err
+						close(done)
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	select {
+	case err := <-errc:
+		if err == ErrStopIteration {
+			return nil
+		}
+		return err
+	default:
+		return nil
+	}
+}
+
+// sortedIndexPool holds *[]int slices of backing array indices for ForEachSorted.
+// Indices are pooled rather than keys so that one pool serves every key type.
+var sortedIndexPool = sync.Pool{New: func() any { return new([]int) }}
+
+// ForEachSorted is like ForEach, but calls f in the order of the keys given by less.
+// f must not modify the map.
+func (m *Map[K, V]) ForEachSorted(less func(K, K) bool, f func(K, V) error) error {
+	p := sortedIndexPool.Get().(*[]int)
+	defer func() {
+		*p = (*p)[:0]
+		sortedIndexPool.Put(p)
+	}()
+	for i, entry := range m.entries {
+		if entry.hash1 != 0 {
+			*p = append(*p, i)
+		}
+	}
+	slices.SortFunc(*p, func(i, j int) int {
+		return compareBy(less, m.entries[i].key, m.entries[j].key)
+	})
+	for _, i := range *p {
+		if err := f(m.entries[i].key, m.entries[i].value); err != nil {
+			if err == ErrStopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// compareBy turns a less function into a three-way comparison for slices.SortFunc.
+func compareBy[K any](less func(K, K) bool, a, b K) int {
+	if less(a, b) {
+		return -1
+	}
+	if less(b, a) {
+		return 1
+	}
+	return 0
+}
+
+// All returns an iterator over all key/value pairs in the map in unspecified order.
+// The map must not be modified during iteration.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, entry := range m.entries {
+			if entry.hash1 != 0 {
+				if !yield(entry.key, entry.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over all keys in the map in unspecified order.
+// The map must not be modified during iteration.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, entry := range m.entries {
+			if entry.hash1 != 0 {
+				if !yield(entry.key) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Values returns an iterator over all values in the map in unspecified order.
+// The map must not be modified during iteration.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, entry := range m.entries {
+			if entry.hash1 != 0 {
+				if !yield(entry.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Entries returns a slice of all key/value pairs in the map in unspecified order.
+func (m *Map[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			entries = append(entries, Entry[K, V]{entry.key, entry.value})
+		}
+	}
+	return entries
+}
+
+// Copy returns a copy of the map.
+// The copy uses the same load factors as the map.
+func (m *Map[K, V]) Copy() *Map[K, V] {
+	c := &Map[K, V]{maxLoad: m.maxLoad, minLoad: m.minLoad, robinHood: m.robinHood, salt: m.salt}
+	if m.size == 0 {
+		return c
+	}
+	c.entries = make([]mapEntry[K, V], len(m.entries))
+	copy(c.entries, m.entries)
+	c.size = m.size
+	return c
+}
+
+// MergeInto adds all key/value pairs from the given map to the map.
+// When a key is present in both maps, resolve is called with the key,
+// the value in the map and the value in the other map, and its result is stored.
+// If resolve is nil, the value already in the map is kept.
+func (m *Map[K, V]) MergeInto(other *Map[K, V], resolve func(K, V, V) V) {
+	if other.size == 0 {
+		return
+	}
+	if m.entries == nil {
+		m.init()
+	}
+	for _, entry := range other.entries {
+		if entry.hash1 != 0 {
+			index, ok := m.findHash1(entry.hash1, entry.key)
+			if !ok {
+				m.insertAt(index, entry.hash1, entry.key, entry.value)
+			} else if resolve != nil {
+				m.entries[index].value = resolve(entry.key, m.entries[index].value, entry.value)
+			}
+		}
+	}
+}
+
+// MergeInPlace adds all key/value pairs from the given map to the map.
+// When a key is present in both maps, resolve is called with the key,
+// the value in the map and the value in the other map, and its result is stored.
+// Unlike MergeInto, if resolve is nil, the value from the other map wins.
+// Room for all of other is reserved up front, so the map is grown at most once.
+func (m *Map[K, V]) MergeInPlace(other *Map[K, V], resolve func(K, V, V) V) {
+	if other.size == 0 {
+		return
+	}
+	m.Reserve(other.size)
+	for _, entry := range other.entries {
+		if entry.hash1 != 0 {
+			index, ok := m.findHash1(entry.hash1, entry.key)
+			if !ok {
+				m.insertAt(index, entry.hash1, entry.key, entry.value)
+			} else if resolve != nil {
+				m.entries[index].value = resolve(entry.key, m.entries[index].value, entry.value)
+			} else {
+				m.entries[index].value = entry.value
+			}
+		}
+	}
+}
+
+// PutAll stores all the given entries in the map.
+// Later entries overwrite earlier ones with the same key.
+// The map is grown at most once.
+func (m *Map[K, V]) PutAll(entries []Entry[K, V]) {
+	if len(entries) == 0 {
+		return
+	}
+	m.Reserve(len(entries))
+	for _, e := range entries {
+		m.putHash1(e.Key.Hash()|fullBit, e.Key, e.Value)
+	}
+}
+
+// PutAllFromMap stores all key/value pairs from the given map in the map,
+// overwriting the values of keys that are already present.
+// The map is grown at most once.
+func (m *Map[K, V]) PutAllFromMap(other *Map[K, V]) {
+	if other.size == 0 {
+		return
+	}
+	m.Reserve(other.size)
+	for _, entry := range other.entries {
+		if entry.hash1 != 0 {
+			m.putHash1(entry.hash1, entry.key, entry.value)
+		}
+	}
+}
+
+// Merge returns a new map with all key/value pairs from both maps.
+// When a key is present in both maps, resolve is called with the key,
+// the value in a and the value in b, and its result is stored.
+// If resolve is nil, the value in a is kept.
+func Merge[K Comparable[K], V any](a, b *Map[K, V], resolve func(K, V, V) V) *Map[K, V] {
+	if a.size >= b.size {
+		r := a.Copy()
+		r.MergeInto(b, resolve)
+		return r
+	}
+	r := b.Copy()
+	for _, entry := range a.entries {
+		if entry.hash1 != 0 {
+			index, ok := r.findHash1(entry.hash1, entry.key)
+			if !ok {
+				r.insertAt(index, entry.hash1, entry.key, entry.value)
+			} else if resolve != nil {
+				r.entries[index].value = resolve(entry.key, entry.value, r.entries[index].value)
+			} else {
+				r.entries[index].value = entry.value
+			}
+		}
+	}
+	return r
+}
+
+var emptyMapHash uint64 = Int(0).Hash()
+
+// Hash returns the hash code for the map.
+// If V implements Comparable, values contribute to the hash; otherwise only keys do.
+// The hash does not depend on the order in which entries were added.
+func (m *Map[K, V]) Hash() uint64 {
+	h := emptyMapHash
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			eh := entry.hash1
+			if c, ok := any(entry.value).(interface{ Hash() uint64 }); ok {
+				eh = hash64bits(eh ^ hash64bits(c.Hash()))
+			}
+			h ^= eh
+		}
+	}
+	return h
+}
+
+// Equals returns true if the map is equal to the given map.
+// Two maps are equal if they have the same keys and the values for each key are equal.
+// Values are compared with Equals if V implements Comparable and with == otherwise,
+// which panics if the values are not comparable.
+func (m *Map[K, V]) Equals(other *Map[K, V]) bool {
+	if m.size != other.size {
+		return false
+	}
+	if m.size == 0 {
+		return true
+	}
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			index, ok := other.findHash1(entry.hash1, entry.key)
+			if !ok || !valuesEqual(entry.value, other.entries[index].value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func valuesEqual[V any](a, b V) bool {
+	if c, ok := any(a).(Comparable[V]); ok {
+		return c.Equals(b)
+	}
+	return any(a) == any(b)
+}
+
+// Filter returns a new map with the key/value pairs for which f returns true.
+func (m *Map[K, V]) Filter(f func(K, V) bool) *Map[K, V] {
+	return FilterMap(m, f)
+}
+
+// Partition returns two new maps: one with the key/value pairs for which f returns true,
+// and one with the rest. The map is traversed only once.
+func (m *Map[K, V]) Partition(f func(K, V) bool) (*Map[K, V], *Map[K, V]) {
+	in, out := m.newLike(m.size), m.newLike(m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			if f(entry.key, entry.value) {
+				in.putHash1(entry.hash1, entry.key, entry.value)
+			} else {
+				out.putHash1(entry.hash1, entry.key, entry.value)
+			}
+		}
+	}
+	return in, out
+}
+
+// SelectKeys returns a new map with only the given keys that are in the map.
+func (m *Map[K, V]) SelectKeys(keys ...K) *Map[K, V] {
+	if len(keys) >= m.size {
+		return m.SelectKeysSet(SetFromSlice(keys))
+	}
+	r := m.newLike(len(keys))
+	for _, key := range keys {
+		hash1 := key.Hash() | fullBit
+		if index, ok := m.findHash1(hash1, key); ok {
+			r.putHash1(hash1, key, m.entries[index].value)
+		}
+	}
+	return r
+}
+
+// SelectKeysSet returns a new map with only the keys of s that are in the map.
+func (m *Map[K, V]) SelectKeysSet(s *Set[K]) *Map[K, V] {
+	if s.size == 0 || m.size == 0 {
+		return m.newLike(0)
+	}
+	if s.size < m.size {
+		r := m.newLike(s.size)
+		for _, entry := range s.entries {
+			if entry.hash1 != 0 {
+				if index, ok := m.findHash1(entry.hash1, entry.key); ok {
+					r.putHash1(entry.hash1, entry.key, m.entries[index].value)
+				}
+			}
+		}
+		return r
+	}
+	r := m.newLike(m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && s.containsHash1Key(entry.hash1, entry.key) {
+			r.putHash1(entry.hash1, entry.key, entry.value)
+		}
+	}
+	return r
+}
+
+// ExcludeKeys returns a copy of the map without the given keys.
+func (m *Map[K, V]) ExcludeKeys(keys ...K) *Map[K, V] {
+	if len(keys) >= m.size {
+		return m.ExcludeKeysSet(SetFromSlice(keys))
+	}
+	r := m.Copy()
+	r.RemoveAll(keys)
+	return r
+}
+
+// ExcludeKeysSet returns a copy of the map without the keys of s.
+func (m *Map[K, V]) ExcludeKeysSet(s *Set[K]) *Map[K, V] {
+	if s.size == 0 || m.size == 0 {
+		return m.Copy()
+	}
+	if s.size < m.size {
+		r := m.Copy()
+		for _, entry := range s.entries {
+			if entry.hash1 != 0 {
+				if index, ok := r.findHash1(entry.hash1, entry.key); ok {
+					r.deleteAt(index)
+				}
+			}
+		}
+		r.shrinkToFit()
+		return r
+	}
+	r := m.newLike(m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && !s.containsHash1Key(entry.hash1, entry.key) {
+			r.putHash1(entry.hash1, entry.key, entry.value)
+		}
+	}
+	return r
+}
+
+// Intersect returns a new map with the entries of the map whose keys are also in other.
+func (m *Map[K, V]) Intersect(other *Map[K, V]) *Map[K, V] {
+	if other.size == 0 || m.size == 0 {
+		return m.newLike(0)
+	}
+	if other.size < m.size {
+		r := m.newLike(other.size)
+		for _, entry := range other.entries {
+			if entry.hash1 != 0 {
+				if index, ok := m.findHash1(entry.hash1, entry.key); ok {
+					r.putHash1(entry.hash1, entry.key, m.entries[index].value)
+				}
+			}
+		}
+		return r
+	}
+	r := m.newLike(m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			if _, ok := other.findHash1(entry.hash1, entry.key); ok {
+				r.putHash1(entry.hash1, entry.key, entry.value)
+			}
+		}
+	}
+	return r
+}
+
+// Subtract returns a new map with the entries of the map whose keys are not in other.
+func (m *Map[K, V]) Subtract(other *Map[K, V]) *Map[K, V] {
+	if other.size == 0 || m.size == 0 {
+		return m.Copy()
+	}
+	if other.size < m.size {
+		r := m.Copy()
+		for _, entry := range other.entries {
+			if entry.hash1 != 0 {
+				if index, ok := r.findHash1(entry.hash1, entry.key); ok {
+					r.deleteAt(index)
+				}
+			}
+		}
+		r.shrinkToFit()
+		return r
+	}
+	r := m.newLike(m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			if _, ok := other.findHash1(entry.hash1, entry.key); !ok {
+				r.putHash1(entry.hash1, entry.key, entry.value)
+			}
+		}
+	}
+	return r
+}
+
+// ReplaceAll replaces each value in the map with the result of calling f on its key and value.
+// The map is updated in place and never resized. f must not modify the map.
+func (m *Map[K, V]) ReplaceAll(f func(K, V) V) {
+	for i := range m.entries {
+		if m.entries[i].hash1 != 0 {
+			m.entries[i].value = f(m.entries[i].key, m.entries[i].value)
+		}
+	}
+}
+
+// Any returns true if f returns true for at least one key/value pair in the map.
+// It returns false for an empty map.
+func (m *Map[K, V]) Any(f func(K, V) bool) bool {
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && f(entry.key, entry.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every returns true if f returns true for every key/value pair in the map.
+// It returns true for an empty map.
+func (m *Map[K, V]) Every(f func(K, V) bool) bool {
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && !f(entry.key, entry.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of key/value pairs in the map for which f returns true.
+func (m *Map[K, V]) Count(f func(K, V) bool) int {
+	n := 0
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && f(entry.key, entry.value) {
+			n++
+		}
+	}
+	return n
+}
+
+// FilterMap returns a new map with the key/value pairs of m for which f returns true.
+func FilterMap[K Comparable[K], V any](m *Map[K, V], f func(K, V) bool) *Map[K, V] {
+	r := m.newLike(m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && f(entry.key, entry.value) {
+			r.putHash1(entry.hash1, entry.key, entry.value)
+		}
+	}
+	return r
+}
+
+// Reduce applies f cumulatively to the key/value pairs of m in unspecified order,
+// starting with initial, and returns the result.
+func Reduce[K Comparable[K], V, R any](m *Map[K, V], initial R, f func(R, K, V) R) R {
+	r := initial
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			r = f(r, entry.key, entry.value)
+		}
+	}
+	return r
+}
+
+// ContainsValueComparable returns true if any value in m is equal to val.
+// It scans the whole map in the worst case.
+func ContainsValueComparable[K Comparable[K], V comparable](m *Map[K, V], val V) bool {
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 && entry.value == val {
+			return true
+		}
+	}
+	return false
+}
+
+// EqualKeys returns true if a and b have the same keys, regardless of their values.
+func EqualKeys[K Comparable[K], V any](a, b *Map[K, V]) bool {
+	if a.size != b.size {
+		return false
+	}
+	if a.size == 0 {
+		return true
+	}
+	for _, entry := range a.entries {
+		if entry.hash1 != 0 {
+			if _, ok := b.findHash1(entry.hash1, entry.key); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// EqualValues returns true if a and b have the same size and every key present
+// in both maps maps to the same value. Keys present in only one map are ignored,
+// so maps of the same size with no keys in common are always equal under EqualValues.
+// Use Equals to also require the same keys.
+func EqualValues[K Comparable[K], V comparable](a, b *Map[K, V]) bool {
+	if a.size != b.size {
+		return false
+	}
+	if a.size == 0 {
+		return true
+	}
+	for _, entry := range a.entries {
+		if entry.hash1 != 0 {
+			index, ok := b.findHash1(entry.hash1, entry.key)
+			if ok && b.entries[index].value != entry.value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MapValues returns a new map with the keys of m and the values returned by f.
+func MapValues[K Comparable[K], V, W any](m *Map[K, V], f func(K, V) W) *Map[K, W] {
+	r := &Map[K, W]{maxLoad: m.maxLoad, minLoad: m.minLoad, robinHood: m.robinHood, salt: m.salt}
+	if m.size == 0 {
+		return r
+	}
+	// The keys are unchanged, so every entry keeps its slot.
+	r.entries = make([]mapEntry[K, W], len(m.entries))
+	for i, entry := range m.entries {
+		if entry.hash1 != 0 {
+			r.entries[i] = mapEntry[K, W]{entry.hash1, entry.key, f(entry.key, entry.value)}
+		}
+	}
+	r.size = m.size
+	return r
+}
+
+// MapKeys returns a new map with the keys returned by f and the values of m.
+// If f returns the same key for several entries, one of their values is kept.
+func MapKeys[K1 Comparable[K1], K2 Comparable[K2], V any](m *Map[K1, V], f func(K1) K2) *Map[K2, V] {
+	r := NewMap[K2, V](m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			r.Put(f(entry.key), entry.value)
+		}
+	}
+	return r
+}
+
+// CollectMap returns a new map with the key/value pairs from seq.
+// Later pairs overwrite earlier ones with the same key.
+func CollectMap[K Comparable[K], V any](seq iter.Seq2[K, V]) *Map[K, V] {
+	r := &Map[K, V]{}
+	for k, v := range seq {
+		r.Put(k, v)
+	}
+	return r
+}
+
+// MapFromSlice returns a new map from the key returned by key for each item to the item.
+// Later items overwrite earlier ones with the same key.
+func MapFromSlice[K Comparable[K], V any](items []V, key func(V) K) *Map[K, V] {
+	r := NewMap[K, V](len(items))
+	for _, item := range items {
+		r.Put(key(item), item)
+	}
+	return r
+}
+
+// FlatMap returns a new map with the entries returned by f for each key/value pair of m.
+// If several entries have the same key, the last one inserted wins.
+func FlatMap[K1 Comparable[K1], K2 Comparable[K2], V1, V2 any](m *Map[K1, V1], f func(K1, V1) []Entry[K2, V2]) *Map[K2, V2] {
+	r := NewMap[K2, V2](m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			for _, e := range f(entry.key, entry.value) {
+				r.Put(e.Key, e.Value)
+			}
+		}
+	}
+	return r
+}
+
+// Zipped holds the values associated with a key in the two maps passed to Zip.
+// Unlike Pair, it accepts any value types.
+type Zipped[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a new map with the keys that are in both a and b,
+// each associated with its values in a and b.
+func Zip[K Comparable[K], A, B any](a *Map[K, A], b *Map[K, B]) *Map[K, Zipped[A, B]] {
+	r := NewMap[K, Zipped[A, B]](min(a.size, b.size))
+	if a.size == 0 || b.size == 0 {
+		return r
+	}
+	for _, entry := range a.entries {
+		if entry.hash1 != 0 {
+			if index, ok := b.findHash1(entry.hash1, entry.key); ok {
+				r.putHash1(entry.hash1, entry.key, Zipped[A, B]{entry.value, b.entries[index].value})
+			}
+		}
+	}
+	return r
+}
+
+// Joined holds the values associated with a key in the two maps passed to ZipAll.
+// A field is nil if the key is not in that map.
+type Joined[A, B any] struct {
+	A *A
+	B *B
+}
+
+// ZipAll returns a new map with the keys that are in a or b,
+// each associated with its values in a and b.
+func ZipAll[K Comparable[K], A, B any](a *Map[K, A], b *Map[K, B]) *Map[K, Joined[A, B]] {
+	r := NewMap[K, Joined[A, B]](max(a.size, b.size))
+	for _, entry := range a.entries {
+		if entry.hash1 != 0 {
+			v := entry.value
+			r.putHash1(entry.hash1, entry.key, Joined[A, B]{A: &v})
+		}
+	}
+	for _, entry := range b.entries {
+		if entry.hash1 != 0 {
+			v := entry.value
+			index, ok := r.findHash1(entry.hash1, entry.key)
+			if ok {
+				r.entries[index].value.B = &v
+			} else {
+				r.insertAt(index, entry.hash1, entry.key, Joined[A, B]{B: &v})
+			}
+		}
+	}
+	return r
+}
+
+// Histogram returns a map from each distinct item to the number of times it occurs.
+func Histogram[K Comparable[K]](items []K) *Map[K, Int] {
+	r := &Map[K, Int]{}
+	for _, item := range items {
+		*r.GetOrInsertZero(item)++
+	}
+	return r
+}
+
+// HistogramBy is like Histogram, but counts the keys returned by key for each item.
+func HistogramBy[E any, K Comparable[K]](items []E, key func(E) K) *Map[K, Int] {
+	r := &Map[K, Int]{}
+	for _, item := range items {
+		*r.GetOrInsertZero(key(item))++
+	}
+	return r
+}
+
+// GroupBy returns a map from each key returned by key to the items that produced it,
+// in their original order.
+func GroupBy[E any, K Comparable[K]](items []E, key func(E) K) *Map[K, []E] {
+	return GroupByInto(items, key, func(e E) E { return e })
+}
+
+// GroupByInto is like GroupBy, but stores the result of val for each item instead of the item.
+func GroupByInto[E any, K Comparable[K], V any](items []E, key func(E) K, val func(E) V) *Map[K, []V] {
+	r := &Map[K, []V]{}
+	for _, item := range items {
+		k := key(item)
+		r.Compute(k, func(k K, vs []V, ok bool) []V {
+			return append(vs, val(item))
+		})
+	}
+	return r
+}
+
+// InvertMap returns a new map with the keys and values of m swapped.
+// If several keys have the same value, one of them is kept; which one is unspecified.
+func InvertMap[K Comparable[K], V Comparable[V]](m *Map[K, V]) *Map[V, K] {
+	r := NewMap[V, K](m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			r.Put(entry.value, entry.key)
+		}
+	}
+	return r
+}
+
+// InvertMapMulti returns a new map from each value of m to the set of keys that have it.
+func InvertMapMulti[K Comparable[K], V Comparable[V]](m *Map[K, V]) *Map[V, *Set[K]] {
+	r := &Map[V, *Set[K]]{}
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			keys := r.ComputeIfAbsent(entry.value, func(V) *Set[K] { return new(Set[K]) })
+			keys.addHash1Key(entry.hash1, entry.key)
+		}
+	}
+	return r
+}
+
+// DiffMaps compares two versions of a map.
+// It returns the keys only in updated, the keys only in old,
+// and for each key whose value changed, its old and updated values.
+func DiffMaps[K Comparable[K], V comparable](old, updated *Map[K, V]) (added, removed *Set[K], changed *Map[K, [2]V]) {
+	added, removed, changed = new(Set[K]), new(Set[K]), new(Map[K, [2]V])
+	for _, entry := range old.entries {
+		if entry.hash1 != 0 {
+			var index uint64
+			ok := updated.size > 0
+			if ok {
+				index, ok = updated.findHash1(entry.hash1, entry.key)
+			}
+			if !ok {
+				removed.addHash1Key(entry.hash1, entry.key)
+			} else if v := updated.entries[index].value; v != entry.value {
+				changed.Put(entry.key, [2]V{entry.value, v})
+			}
+		}
+	}
+	for _, entry := range updated.entries {
+		if entry.hash1 != 0 {
+			if old.size == 0 {
+				added.addHash1Key(entry.hash1, entry.key)
+			} else if _, ok := old.findHash1(entry.hash1, entry.key); !ok {
+				added.addHash1Key(entry.hash1, entry.key)
+			}
+		}
+	}
+	return added, removed, changed
 }
@@ -1,8 +1,14 @@
 package hashmap
 
 import (
+	"errors"
 	"fmt"
 	"hash/maphash"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sort"
+	"sync/atomic"
 	"testing"
 )
 
@@ -39,6 +45,1511 @@ func TestMap(t *testing.T) {
 	}
 }
 
+func TestMapPutUpdate(t *testing.T) {
+	m := Map[Int, Int]{}
+	m.Put(Int(1), Int(1))
+	m.Put(Int(1), Int(2))
+	if m.Size() != 1 {
+		t.Errorf("expected size 1, got %d", m.Size())
+	}
+	if v, _ := m.Get(Int(1)); v != Int(2) {
+		t.Errorf("expected value 2, got %d", v)
+	}
+}
+
+func TestMapGetOrDefault(t *testing.T) {
+	m := Map[Int, Int]{}
+	m.Put(Int(1), Int(2))
+	if v := m.GetOrDefault(Int(1), Int(-1)); v != Int(2) {
+		t.Errorf("expected value 2, got %d", v)
+	}
+	if v := m.GetOrDefault(Int(3), Int(-1)); v != Int(-1) {
+		t.Errorf("expected value -1, got %d", v)
+	}
+
+	called := false
+	f := func() Int {
+		called = true
+		return Int(-1)
+	}
+	if v := m.GetOrElse(Int(1), f); v != Int(2) || called {
+		t.Errorf("expected value 2 without calling f, got %d", v)
+	}
+	if v := m.GetOrElse(Int(3), f); v != Int(-1) || !called {
+		t.Errorf("expected value -1 from f, got %d", v)
+	}
+}
+
+func TestMapComputeIfAbsent(t *testing.T) {
+	m := Map[Int, Int]{}
+	calls := 0
+	f := func(k Int) Int {
+		calls++
+		return k * 2
+	}
+	for i := 0; i < 100; i++ {
+		if v := m.ComputeIfAbsent(Int(i), f); v != Int(i*2) {
+			t.Errorf("expected value %d, got %d", i*2, v)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if v := m.ComputeIfAbsent(Int(i), f); v != Int(i*2) {
+			t.Errorf("expected value %d, got %d", i*2, v)
+		}
+	}
+	if calls != 100 {
+		t.Errorf("expected 100 calls, got %d", calls)
+	}
+	if m.Size() != 100 {
+		t.Errorf("expected size 100, got %d", m.Size())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic when f modifies the map")
+		}
+	}()
+	m.ComputeIfAbsent(Int(100), func(k Int) Int {
+		m.Put(k, k)
+		return k
+	})
+}
+
+func TestMapComputeIfPresent(t *testing.T) {
+	m := Map[Int, Int]{}
+	inc := func(k Int, v Int) Int {
+		return v + 1
+	}
+	if _, ok := m.ComputeIfPresent(Int(1), inc); ok {
+		t.Errorf("expected to not find key 1 in empty map")
+	}
+	m.Put(Int(1), Int(10))
+	for i := 0; i < 5; i++ {
+		m.ComputeIfPresent(Int(1), inc)
+	}
+	if v, ok := m.ComputeIfPresent(Int(1), inc); !ok || v != Int(16) {
+		t.Errorf("expected value 16, got %d", v)
+	}
+	if v, _ := m.Get(Int(1)); v != Int(16) {
+		t.Errorf("expected value 16, got %d", v)
+	}
+	if _, ok := m.ComputeIfPresent(Int(2), func(k Int, v Int) Int {
+		t.Errorf("expected f to not be called for missing key")
+		return v
+	}); ok {
+		t.Errorf("expected to not find key 2")
+	}
+	if m.Size() != 1 {
+		t.Errorf("expected size 1, got %d", m.Size())
+	}
+}
+
+func TestMapCompute(t *testing.T) {
+	m := Map[Int, Int]{}
+	count := func(k Int, v Int, ok bool) Int {
+		if !ok {
+			return 1
+		}
+		return v + 1
+	}
+	for i := 0; i < 100; i++ {
+		m.Compute(Int(i%10), count)
+	}
+	if m.Size() != 10 {
+		t.Errorf("expected size 10, got %d", m.Size())
+	}
+	for i := 0; i < 10; i++ {
+		if v, _ := m.Get(Int(i)); v != Int(10) {
+			t.Errorf("expected value 10, got %d", v)
+		}
+	}
+	if v := m.Compute(Int(0), count); v != Int(11) {
+		t.Errorf("expected value 11, got %d", v)
+	}
+}
+
+func intMap(kvs ...int) *Map[Int, Int] {
+	m := new(Map[Int, Int])
+	for i := 0; i+1 < len(kvs); i += 2 {
+		m.Put(Int(kvs[i]), Int(kvs[i+1]))
+	}
+	return m
+}
+
+func TestMerge(t *testing.T) {
+	sum := func(k Int, a Int, b Int) Int {
+		return a + b
+	}
+	tests := []struct {
+		a, b    *Map[Int, Int]
+		resolve func(Int, Int, Int) Int
+		r       map[Int]Int
+	}{
+		{intMap(1, 10, 2, 20), intMap(2, 200, 3, 300), nil, map[Int]Int{1: 10, 2: 20, 3: 300}},
+		{intMap(1, 10), intMap(2, 200, 3, 300, 1, 100), nil, map[Int]Int{1: 10, 2: 200, 3: 300}},
+		{intMap(1, 10, 2, 20), intMap(2, 200, 3, 300), sum, map[Int]Int{1: 10, 2: 220, 3: 300}},
+		{intMap(1, 10), intMap(2, 200, 3, 300, 1, 100), sum, map[Int]Int{1: 110, 2: 200, 3: 300}},
+		{intMap(), intMap(1, 10), nil, map[Int]Int{1: 10}},
+		{intMap(1, 10), intMap(), nil, map[Int]Int{1: 10}},
+	}
+	for i, test := range tests {
+		r := Merge(test.a, test.b, test.resolve)
+		if r.Size() != len(test.r) {
+			t.Errorf("test %d: expected size %d, got %d", i, len(test.r), r.Size())
+		}
+		for k, v := range test.r {
+			if got, _ := r.Get(k); got != v {
+				t.Errorf("test %d: expected value %d for key %d, got %d", i, v, k, got)
+			}
+		}
+	}
+}
+
+func TestMapMergeInto(t *testing.T) {
+	m := intMap(1, 10, 2, 20)
+	m.MergeInto(intMap(2, 200, 3, 300), nil)
+	if v, _ := m.Get(Int(2)); v != Int(20) {
+		t.Errorf("expected value 20, got %d", v)
+	}
+	m.MergeInto(intMap(2, 200), func(k Int, a Int, b Int) Int {
+		return b
+	})
+	if v, _ := m.Get(Int(2)); v != Int(200) {
+		t.Errorf("expected value 200, got %d", v)
+	}
+	if m.Size() != 3 {
+		t.Errorf("expected size 3, got %d", m.Size())
+	}
+}
+
+func TestMapKeysValuesEntries(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	keys := slices.Sorted(m.Keys())
+	if !reflect.DeepEqual(keys, []Int{1, 2, 3}) {
+		t.Errorf("expected keys [1 2 3], got %v", keys)
+	}
+	values := slices.Sorted(m.Values())
+	if !reflect.DeepEqual(values, []Int{10, 20, 30}) {
+		t.Errorf("expected values [10 20 30], got %v", values)
+	}
+	entries := m.Entries()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	if !reflect.DeepEqual(entries, []Entry[Int, Int]{{1, 10}, {2, 20}, {3, 30}}) {
+		t.Errorf("expected entries [{1 10} {2 20} {3 30}], got %v", entries)
+	}
+	if len(slices.Collect(intMap().Keys())) != 0 {
+		t.Errorf("expected no keys in empty map")
+	}
+}
+
+func TestMapAll(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	seen := map[Int]Int{}
+	for k, v := range m.All() {
+		seen[k] = v
+	}
+	if !reflect.DeepEqual(seen, map[Int]Int{1: 10, 2: 20, 3: 30}) {
+		t.Errorf("expected all entries, got %v", seen)
+	}
+	n := 0
+	for range m.All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("expected iteration to stop after break, got %d", n)
+	}
+	for range intMap().All() {
+		t.Errorf("expected no entries in empty map")
+	}
+}
+
+func TestMapEquality(t *testing.T) {
+	m1 := intMap(1, 10, 2, 20, 3, 30)
+	m2 := intMap(3, 30, 2, 20, 1, 10)
+	if !m1.Equals(m2) || !m2.Equals(m1) {
+		t.Errorf("expected maps to be equal")
+	}
+	if m1.Hash() != m2.Hash() {
+		t.Errorf("expected equal maps to have equal hashes")
+	}
+	m3 := intMap(1, 10, 2, 30, 3, 20)
+	if m1.Equals(m3) {
+		t.Errorf("expected maps with different values to not be equal")
+	}
+	if m1.Hash() == m3.Hash() {
+		t.Errorf("expected maps with swapped values to hash differently")
+	}
+	if m1.Equals(intMap(1, 10, 2, 20)) {
+		t.Errorf("expected maps with different sizes to not be equal")
+	}
+	m4 := intMap(1, 10)
+	m4.Remove(Int(1))
+	if !intMap().Equals(m4) || intMap().Hash() != m4.Hash() {
+		t.Errorf("expected empty maps to be equal")
+	}
+
+	outer := Map[*Map[Int, Int], String]{}
+	outer.Put(m1, "a")
+	if v, ok := outer.Get(m2); !ok || v != "a" {
+		t.Errorf("expected to find equal map as key")
+	}
+}
+
+func TestMapClear(t *testing.T) {
+	m := Map[Int, Int]{}
+	if !m.IsEmpty() {
+		t.Errorf("expected zero map to be empty")
+	}
+	m.Clear()
+	for _, n := range []int{5, 100} {
+		for i := 0; i < n; i++ {
+			m.Put(Int(i), Int(i))
+		}
+		if m.IsEmpty() {
+			t.Errorf("expected map to not be empty")
+		}
+		m.Clear()
+		if !m.IsEmpty() || m.Size() != 0 {
+			t.Errorf("expected map to be empty after Clear")
+		}
+		if _, ok := m.Get(Int(1)); ok {
+			t.Errorf("expected to not find key 1 after Clear")
+		}
+	}
+	m.Put(Int(1), Int(1))
+	if v, _ := m.Get(Int(1)); v != Int(1) {
+		t.Errorf("expected map to be usable after Clear")
+	}
+}
+
+func TestMapGetAndRemove(t *testing.T) {
+	m := Map[Int, Int]{}
+	if _, ok := m.GetAndRemove(Int(1)); ok {
+		t.Errorf("expected to not find key 1 in empty map")
+	}
+	for i := 0; i < 100; i++ {
+		m.Put(Int(i), Int(i*2))
+	}
+	for i := 0; i < 100; i += 2 {
+		if v, ok := m.GetAndRemove(Int(i)); !ok || v != Int(i*2) {
+			t.Errorf("expected value %d, got %d", i*2, v)
+		}
+	}
+	if _, ok := m.GetAndRemove(Int(0)); ok {
+		t.Errorf("expected to not find removed key 0")
+	}
+	if m.Size() != 50 {
+		t.Errorf("expected size 50, got %d", m.Size())
+	}
+	for i := 1; i < 100; i += 2 {
+		if _, ok := m.Get(Int(i)); !ok {
+			t.Errorf("expected to find key %d", i)
+		}
+	}
+}
+
+func TestMapPop(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	seen := map[Int]Int{}
+	for {
+		k, v, ok := m.Pop()
+		if !ok {
+			break
+		}
+		seen[k] = v
+	}
+	if !reflect.DeepEqual(seen, map[Int]Int{1: 10, 2: 20, 3: 30}) {
+		t.Errorf("expected to pop all entries, got %v", seen)
+	}
+	if m.Size() != 0 {
+		t.Errorf("expected size 0, got %d", m.Size())
+	}
+	// Pops interleaved with puts and shrinking must still find every entry.
+	for _, robinHood := range []bool{false, true} {
+		m := &Map[Int, Int]{robinHood: robinHood}
+		for i := 0; i < 1000; i++ {
+			m.Put(Int(i), Int(i))
+		}
+		popped := map[Int]bool{}
+		for i := 0; ; i++ {
+			if i%3 == 0 && i < 1500 {
+				m.Put(Int(1000+i), Int(i))
+			}
+			k, _, ok := m.Pop()
+			if !ok {
+				break
+			}
+			if popped[k] {
+				t.Fatalf("expected key %d to be popped once", k)
+			}
+			popped[k] = true
+		}
+		if len(popped) != 1500 || m.Size() != 0 {
+			t.Errorf("expected 1500 keys popped and an empty map, got %d and size %d", len(popped), m.Size())
+		}
+	}
+}
+
+func TestNewMap(t *testing.T) {
+	for _, n := range []int{-1, 0, 1, 12, 13, 100, 1000} {
+		m := NewMap[Int, Int](n)
+		c := len(m.entries)
+		for i := 0; i < n; i++ {
+			m.Put(Int(i), Int(i))
+		}
+		if n > 0 && len(m.entries) != c {
+			t.Errorf("expected no resize for capacity %d, got %d -> %d", n, c, len(m.entries))
+		}
+		if n > 0 && m.Size() != n {
+			t.Errorf("expected size %d, got %d", n, m.Size())
+		}
+	}
+	if m := NewMap[Int, Int](0); m.entries != nil {
+		t.Errorf("expected zero capacity map to be the zero value")
+	}
+}
+
+func TestMapReserve(t *testing.T) {
+	m := Map[Int, Int]{}
+	m.Reserve(0)
+	for i := 0; i < 10; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	m.Reserve(1000)
+	c := len(m.entries)
+	for i := 10; i < 1010; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	if len(m.entries) != c {
+		t.Errorf("expected no resize after Reserve, got %d -> %d", c, len(m.entries))
+	}
+	m.Reserve(1)
+	if len(m.entries) != c {
+		t.Errorf("expected Reserve to be a no-op when capacity suffices")
+	}
+	for i := 0; i < 1010; i++ {
+		if v, ok := m.Get(Int(i)); !ok || v != Int(i) {
+			t.Errorf("expected value %d, got %d", i, v)
+		}
+	}
+}
+
+func TestMapDiagnostics(t *testing.T) {
+	m := Map[Int, Int]{}
+	if m.Capacity() != 0 || m.LoadFactor() != 0 || m.MaxProbeLength() != 0 {
+		t.Errorf("expected zero diagnostics for zero map")
+	}
+	for i := 0; i < 12; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	if m.Capacity() != 16 {
+		t.Errorf("expected capacity 16, got %d", m.Capacity())
+	}
+	if m.LoadFactor() != 0.75 {
+		t.Errorf("expected load factor 0.75, got %f", m.LoadFactor())
+	}
+	if l := m.MaxProbeLength(); l < 1 || l > 12 {
+		t.Errorf("expected max probe length between 1 and 12, got %d", l)
+	}
+
+	m = Map[Int, Int]{}
+	m.init()
+	m.entries[15] = mapEntry[Int, Int]{hash1: fullBit}
+	m.entries[0] = mapEntry[Int, Int]{hash1: fullBit}
+	m.entries[1] = mapEntry[Int, Int]{hash1: fullBit}
+	m.entries[5] = mapEntry[Int, Int]{hash1: fullBit}
+	m.size = 4
+	if l := m.MaxProbeLength(); l != 3 {
+		t.Errorf("expected wrapped max probe length 3, got %d", l)
+	}
+}
+
+func TestMapWithLoadFactor(t *testing.T) {
+	m := NewMap[Int, Int](0, WithLoadFactor[Int, Int](0.5, 0.1))
+	for i := 0; i < 9; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	if m.Capacity() != 32 {
+		t.Errorf("expected capacity 32, got %d", m.Capacity())
+	}
+	for i := 0; i < 5; i++ {
+		m.Remove(Int(i))
+	}
+	if m.Capacity() != 32 {
+		t.Errorf("expected capacity 32 above minimum load factor, got %d", m.Capacity())
+	}
+	m.Remove(Int(5))
+	if m.Capacity() != 16 {
+		t.Errorf("expected capacity 16 below minimum load factor, got %d", m.Capacity())
+	}
+	if c := m.Copy(); c.maxLoad != 0.5 || c.minLoad != 0.1 {
+		t.Errorf("expected copy to keep load factors")
+	}
+	if c := NewMap[Int, Int](100, WithLoadFactor[Int, Int](0.9, 0.2)); c.Capacity() != 128 {
+		t.Errorf("expected capacity 128, got %d", c.Capacity())
+	}
+
+	for _, lf := range [][2]float64{{0.5, 0.5}, {0.5, 0}, {1, 0.5}, {0.2, 0.5}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for load factors %v", lf)
+				}
+			}()
+			WithLoadFactor[Int, Int](lf[0], lf[1])
+		}()
+	}
+}
+
+func TestMapForEachStop(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	n := 0
+	err := m.ForEach(func(k Int, v Int) error {
+		n++
+		return ErrStopIteration
+	})
+	if err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 call, got %d", n)
+	}
+	errTest := errors.New("test")
+	if err := m.ForEach(func(k Int, v Int) error { return errTest }); err != errTest {
+		t.Errorf("expected test error, got %v", err)
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30, 4, 40)
+	r := FilterMap(m, func(k Int, v Int) bool { return k%2 == 0 })
+	if !r.Equals(intMap(2, 20, 4, 40)) {
+		t.Errorf("expected even keys, got %v", r.Entries())
+	}
+	if m.Size() != 4 {
+		t.Errorf("expected source map to be unchanged")
+	}
+	if r := FilterMap(intMap(), func(k Int, v Int) bool { return true }); r.Size() != 0 {
+		t.Errorf("expected empty result for empty map")
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	r := MapValues(m, func(k Int, v Int) String {
+		return String(fmt.Sprint(k + v))
+	})
+	if r.Size() != 3 {
+		t.Errorf("expected size 3, got %d", r.Size())
+	}
+	for _, k := range []Int{1, 2, 3} {
+		if v, _ := r.Get(k); v != String(fmt.Sprint(k*11)) {
+			t.Errorf("expected value %d, got %s", k*11, v)
+		}
+	}
+	r.Put(4, "44")
+	if r.Size() != 4 || m.Size() != 3 {
+		t.Errorf("expected result to be independent of the source")
+	}
+	if MapValues(intMap(), func(k Int, v Int) Int { return v }).Size() != 0 {
+		t.Errorf("expected empty result for empty map")
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	r := MapKeys(m, func(k Int) String {
+		return String(fmt.Sprint(k))
+	})
+	if v, ok := r.Get("2"); r.Size() != 3 || !ok || v != 20 {
+		t.Errorf("expected key \"2\" to map to 20, got %d", v)
+	}
+	r2 := MapKeys(m, func(k Int) Int { return k % 2 })
+	if r2.Size() != 2 {
+		t.Errorf("expected colliding keys to be merged, got size %d", r2.Size())
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	r := GroupBy(words, func(w string) String { return String(w[:1]) })
+	if r.Size() != 3 {
+		t.Errorf("expected 3 groups, got %d", r.Size())
+	}
+	if v, _ := r.Get("b"); !reflect.DeepEqual(v, []string{"banana", "blueberry"}) {
+		t.Errorf("expected [banana blueberry], got %v", v)
+	}
+
+	lengths := GroupByInto(words, func(w string) String { return String(w[:1]) }, func(w string) int { return len(w) })
+	if v, _ := lengths.Get("a"); !reflect.DeepEqual(v, []int{5, 7}) {
+		t.Errorf("expected [5 7], got %v", v)
+	}
+	if GroupBy([]string{}, func(w string) String { return String(w) }).Size() != 0 {
+		t.Errorf("expected no groups for no items")
+	}
+}
+
+func TestInvertMap(t *testing.T) {
+	r := InvertMap(intMap(1, 10, 2, 20, 3, 30))
+	if !r.Equals(intMap(10, 1, 20, 2, 30, 3)) {
+		t.Errorf("expected inverted map, got %v", r.Entries())
+	}
+	r = InvertMap(intMap(1, 10, 2, 10))
+	if v, _ := r.Get(10); r.Size() != 1 || (v != 1 && v != 2) {
+		t.Errorf("expected one of the keys to win, got %v", r.Entries())
+	}
+}
+
+func TestInvertMapMulti(t *testing.T) {
+	r := InvertMapMulti(intMap(1, 10, 2, 10, 3, 30))
+	if r.Size() != 2 {
+		t.Errorf("expected 2 values, got %d", r.Size())
+	}
+	if keys, _ := r.Get(10); !keys.Equals(intSet(1, 2)) {
+		t.Errorf("expected keys {1 2} for value 10, got %v", keys.ToSlice())
+	}
+	if keys, _ := r.Get(30); !keys.Equals(intSet(3)) {
+		t.Errorf("expected keys {3} for value 30, got %v", keys.ToSlice())
+	}
+}
+
+func TestDiffMaps(t *testing.T) {
+	old := intMap(1, 10, 2, 20, 3, 30)
+	updated := intMap(2, 20, 3, 33, 4, 40)
+	added, removed, changed := DiffMaps(old, updated)
+	if !added.Equals(intSet(4)) {
+		t.Errorf("expected added {4}, got %v", added.ToSlice())
+	}
+	if !removed.Equals(intSet(1)) {
+		t.Errorf("expected removed {1}, got %v", removed.ToSlice())
+	}
+	if changed.Size() != 1 {
+		t.Errorf("expected 1 changed key, got %d", changed.Size())
+	}
+	if v, _ := changed.Get(3); v != [2]Int{30, 33} {
+		t.Errorf("expected key 3 to change from 30 to 33, got %v", v)
+	}
+	if _, ok := changed.Get(2); ok {
+		t.Errorf("expected unchanged key 2 to not be reported")
+	}
+
+	added, removed, changed = DiffMaps(intMap(), intMap(1, 10))
+	if !added.Equals(intSet(1)) || removed.Size() != 0 || changed.Size() != 0 {
+		t.Errorf("unexpected diff against empty map")
+	}
+	added, removed, changed = DiffMaps(intMap(1, 10), intMap())
+	if added.Size() != 0 || !removed.Equals(intSet(1)) || changed.Size() != 0 {
+		t.Errorf("unexpected diff to empty map")
+	}
+}
+
+func TestMapFilter(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30, 4, 40)
+	even := func(k, v Int) bool { return k%2 == 0 }
+	if r := m.Filter(even); !r.Equals(intMap(2, 20, 4, 40)) {
+		t.Errorf("expected {2:20 4:40}, got %v", r)
+	}
+	if m.Size() != 4 {
+		t.Errorf("expected original map to be unchanged, got %v", m)
+	}
+	if r := intMap().Filter(even); r.Size() != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+}
+
+func TestMapPartition(t *testing.T) {
+	tests := []struct {
+		m, in, out *Map[Int, Int]
+	}{
+		{intMap(1, 10, 2, 20, 3, 30, 4, 40), intMap(2, 20, 4, 40), intMap(1, 10, 3, 30)},
+		{intMap(2, 20), intMap(2, 20), intMap()},
+		{intMap(1, 10), intMap(), intMap(1, 10)},
+		{intMap(), intMap(), intMap()},
+	}
+	for _, test := range tests {
+		in, out := test.m.Partition(func(k, v Int) bool { return k%2 == 0 })
+		if !in.Equals(test.in) || !out.Equals(test.out) {
+			t.Errorf("expected partition of %v to be %v and %v, got %v and %v", test.m, test.in, test.out, in, out)
+		}
+	}
+}
+
+func TestMapAnyEveryCount(t *testing.T) {
+	big := func(k, v Int) bool { return v > 15 }
+	tests := []struct {
+		m          *Map[Int, Int]
+		any, every bool
+		count      int
+	}{
+		{intMap(1, 20, 2, 30), true, true, 2},
+		{intMap(1, 10, 2, 20, 3, 30), true, false, 2},
+		{intMap(1, 10), false, false, 0},
+		{intMap(), false, true, 0},
+	}
+	for _, test := range tests {
+		if r := test.m.Any(big); r != test.any {
+			t.Errorf("expected Any of %v to be %v, got %v", test.m, test.any, r)
+		}
+		if r := test.m.Every(big); r != test.every {
+			t.Errorf("expected Every of %v to be %v, got %v", test.m, test.every, r)
+		}
+		if r := test.m.Count(big); r != test.count {
+			t.Errorf("expected Count of %v to be %d, got %d", test.m, test.count, r)
+		}
+	}
+	n := 0
+	intMap(1, 10, 2, 20).Any(func(k, v Int) bool { n++; return true })
+	if n != 1 {
+		t.Errorf("expected Any to stop after first match, got %d calls", n)
+	}
+}
+
+func ExampleReduce() {
+	m := Map[String, Int]{}
+	m.Put("a", 3)
+	m.Put("b", 7)
+	m.Put("c", 5)
+	sum := Reduce(&m, 0, func(r int, k String, v Int) int { return r + int(v) })
+	largest := Reduce(&m, Int(0), func(r Int, k String, v Int) Int { return max(r, v) })
+	fmt.Println(sum, largest)
+	// Output: 15 7
+}
+
+func TestMapMustGet(t *testing.T) {
+	m := intMap(1, 10)
+	if v := m.MustGet(1); v != 10 {
+		t.Errorf("expected 10, got %d", v)
+	}
+	defer func() {
+		if r := recover(); r != "hashmap: key 2 not found" {
+			t.Errorf("expected panic with \"hashmap: key 2 not found\", got %v", r)
+		}
+	}()
+	m.MustGet(2)
+}
+
+func TestMapGetPointer(t *testing.T) {
+	m := Map[Int, [4]int]{}
+	if p := m.GetPointer(1); p != nil {
+		t.Errorf("expected nil pointer for empty map, got %v", *p)
+	}
+	m.Put(1, [4]int{1, 2, 3, 4})
+	if p := m.GetPointer(2); p != nil {
+		t.Errorf("expected nil pointer for missing key, got %v", *p)
+	}
+	p := m.GetPointer(1)
+	if p == nil {
+		t.Fatal("expected pointer for key 1")
+	}
+	p[2] = 30
+	if v, _ := m.Get(1); v != [4]int{1, 2, 30, 4} {
+		t.Errorf("expected [1 2 30 4], got %v", v)
+	}
+}
+
+func TestMapGetOrInsert(t *testing.T) {
+	m := Map[Int, Int]{}
+	for i := 0; i < 100; i++ {
+		*m.GetOrInsertZero(Int(i % 10))++
+	}
+	for i := 0; i < 10; i++ {
+		if v, _ := m.Get(Int(i)); v != 10 {
+			t.Errorf("expected count 10 for key %d, got %d", i, v)
+		}
+	}
+	// Insert enough keys to force several resizes and check each returned pointer.
+	for i := 10; i < 100; i++ {
+		p := m.GetOrInsert(Int(i), Int(i))
+		if *p != Int(i) {
+			t.Errorf("expected %d, got %d", i, *p)
+		}
+		*p = Int(-i)
+	}
+	for i := 10; i < 100; i++ {
+		if v, _ := m.Get(Int(i)); v != Int(-i) {
+			t.Errorf("expected %d, got %d", -i, v)
+		}
+	}
+	if p := m.GetOrInsert(5, 99); *p != 10 {
+		t.Errorf("expected existing value 10, got %d", *p)
+	}
+	if m.Size() != 100 {
+		t.Errorf("expected size 100, got %d", m.Size())
+	}
+}
+
+func TestMapUpsert(t *testing.T) {
+	m := Map[Int, Int]{}
+	insert := func(k Int) Int { return k * 10 }
+	update := func(k, v Int) Int { return v + 1 }
+	if v := m.Upsert(1, insert, update); v != 10 {
+		t.Errorf("expected inserted value 10, got %d", v)
+	}
+	if v := m.Upsert(1, insert, update); v != 11 {
+		t.Errorf("expected updated value 11, got %d", v)
+	}
+	if v, _ := m.Get(1); v != 11 {
+		t.Errorf("expected stored value 11, got %d", v)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic when insert modifies the map")
+		}
+	}()
+	m.Upsert(2, func(k Int) Int { m.Put(3, 3); return 0 }, update)
+}
+
+func TestMapSwap(t *testing.T) {
+	tests := []struct {
+		m      *Map[Int, Int]
+		k1, k2 Int
+		ok     bool
+		r      *Map[Int, Int]
+	}{
+		{intMap(1, 10, 2, 20), 1, 2, true, intMap(1, 20, 2, 10)},
+		{intMap(1, 10, 2, 20), 1, 1, true, intMap(1, 10, 2, 20)},
+		{intMap(1, 10, 2, 20), 1, 3, false, intMap(1, 10, 2, 20)},
+		{intMap(1, 10, 2, 20), 3, 2, false, intMap(1, 10, 2, 20)},
+		{intMap(), 1, 2, false, intMap()},
+	}
+	for _, test := range tests {
+		if ok := test.m.Swap(test.k1, test.k2); ok != test.ok {
+			t.Errorf("expected Swap(%d, %d) to return %v, got %v", test.k1, test.k2, test.ok, ok)
+		}
+		if !test.m.Equals(test.r) {
+			t.Errorf("expected %v, got %v", test.r, test.m)
+		}
+	}
+	// Keys with the same hash share a home slot.
+	m := Map[bigKey, Int]{}
+	a, b := bigKey{a: "xy"}, bigKey{a: "x", b: "y"}
+	m.Put(a, 1)
+	m.Put(b, 2)
+	if !m.Swap(a, b) {
+		t.Fatal("expected Swap to succeed")
+	}
+	if va, _ := m.Get(a); va != 2 {
+		t.Errorf("expected 2, got %d", va)
+	}
+	if vb, _ := m.Get(b); vb != 1 {
+		t.Errorf("expected 1, got %d", vb)
+	}
+}
+
+func TestMapRename(t *testing.T) {
+	tests := []struct {
+		name           string
+		oldKey, newKey Int
+		ok             bool
+		expected       *Map[Int, Int]
+	}{
+		{"identity", 1, 1, true, intMap(1, 10, 2, 20, 3, 30)},
+		{"new key", 1, 4, true, intMap(4, 10, 2, 20, 3, 30)},
+		{"overwrite", 1, 2, true, intMap(2, 10, 3, 30)},
+		{"missing", 5, 6, false, intMap(1, 10, 2, 20, 3, 30)},
+		{"missing identity", 5, 5, false, intMap(1, 10, 2, 20, 3, 30)},
+	}
+	for _, test := range tests {
+		for _, robinHood := range []bool{false, true} {
+			m := intMap(1, 10, 2, 20, 3, 30)
+			m.robinHood = robinHood
+			if ok := m.Rename(test.oldKey, test.newKey); ok != test.ok {
+				t.Errorf("%s: expected %v, got %v", test.name, test.ok, ok)
+			}
+			if !m.Equals(test.expected) {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected, m)
+			}
+		}
+	}
+	if intMap().Rename(1, 2) {
+		t.Errorf("expected Rename on an empty map to fail")
+	}
+}
+
+func TestMapPutRemoveReturningOld(t *testing.T) {
+	m := Map[Int, Int]{}
+	if old, ok := m.PutReturningOld(1, 10); ok || old != 0 {
+		t.Errorf("expected (0, false), got (%d, %v)", old, ok)
+	}
+	if old, ok := m.PutReturningOld(1, 11); !ok || old != 10 {
+		t.Errorf("expected (10, true), got (%d, %v)", old, ok)
+	}
+	if old, ok := m.RemoveReturningOld(1); !ok || old != 11 {
+		t.Errorf("expected (11, true), got (%d, %v)", old, ok)
+	}
+	if old, ok := m.RemoveReturningOld(1); ok || old != 0 {
+		t.Errorf("expected (0, false), got (%d, %v)", old, ok)
+	}
+	if m.Size() != 0 {
+		t.Errorf("expected empty map, got %v", &m)
+	}
+}
+
+func TestMapPutAll(t *testing.T) {
+	m := intMap(1, 10)
+	m.PutAll([]Entry[Int, Int]{{2, 20}, {1, 11}, {3, 30}, {2, 21}})
+	if r := intMap(1, 11, 2, 21, 3, 30); !m.Equals(r) {
+		t.Errorf("expected %v, got %v", r, m)
+	}
+	m.PutAll(nil)
+	if m.Size() != 3 {
+		t.Errorf("expected size 3, got %d", m.Size())
+	}
+	entries := make([]Entry[Int, Int], 100)
+	for i := range entries {
+		entries[i] = Entry[Int, Int]{Int(i), Int(i)}
+	}
+	big := Map[Int, Int]{}
+	big.PutAll(entries)
+	if big.Size() != 100 || big.Capacity() != capacityFor(100, defaultMaxLoadFactor) {
+		t.Errorf("expected 100 entries in a single allocation, got size %d, capacity %d", big.Size(), big.Capacity())
+	}
+}
+
+func TestMapPutAllFromMap(t *testing.T) {
+	m := intMap(1, 10, 2, 20)
+	m.PutAllFromMap(intMap(2, 21, 3, 30))
+	if r := intMap(1, 10, 2, 21, 3, 30); !m.Equals(r) {
+		t.Errorf("expected %v, got %v", r, m)
+	}
+	e := Map[Int, Int]{}
+	e.PutAllFromMap(intMap())
+	if e.Capacity() != 0 {
+		t.Errorf("expected no allocation, got capacity %d", e.Capacity())
+	}
+}
+
+func TestMapRemoveAll(t *testing.T) {
+	m := Map[Int, Int]{}
+	keys := make([]Int, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		m.Put(Int(i), Int(i))
+		if i%10 != 0 {
+			keys = append(keys, Int(i))
+		}
+	}
+	m.RemoveAll(keys)
+	m.RemoveAll([]Int{2000})
+	if m.Size() != 100 {
+		t.Errorf("expected size 100, got %d", m.Size())
+	}
+	if c := capacityFor(100, defaultMaxLoadFactor); m.Capacity() > 2*c {
+		t.Errorf("expected map to shrink to at most %d, got capacity %d", 2*c, m.Capacity())
+	}
+	for i := 0; i < 1000; i++ {
+		if _, ok := m.Get(Int(i)); ok != (i%10 == 0) {
+			t.Errorf("expected Get(%d) to return %v, got %v", i, i%10 == 0, ok)
+		}
+	}
+	e := Map[Int, Int]{}
+	e.RemoveAll([]Int{1})
+	if e.Size() != 0 {
+		t.Errorf("expected empty map, got %v", &e)
+	}
+}
+
+func TestCollectMap(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	if r := CollectMap(m.All()); !r.Equals(m) {
+		t.Errorf("expected %v, got %v", m, r)
+	}
+	seq := func(yield func(Int, Int) bool) {
+		_ = yield(1, 10) && yield(1, 11)
+	}
+	if r := CollectMap(seq); !r.Equals(intMap(1, 11)) {
+		t.Errorf("expected {1:11}, got %v", r)
+	}
+}
+
+func TestMapFromSlice(t *testing.T) {
+	items := []String{"apple", "avocado", "banana"}
+	m := MapFromSlice(items, func(s String) String { return s[:1] })
+	if m.Size() != 2 {
+		t.Errorf("expected 2 entries, got %v", m)
+	}
+	if v, _ := m.Get("a"); v != "avocado" {
+		t.Errorf("expected later item to win, got %q", v)
+	}
+	if v, _ := m.Get("b"); v != "banana" {
+		t.Errorf("expected banana, got %q", v)
+	}
+}
+
+func TestMapForEachSorted(t *testing.T) {
+	less := func(a, b Int) bool { return a < b }
+	var first []Int
+	for run := 0; run < 3; run++ {
+		m := Map[Int, Int]{}
+		for i := 0; i < 100; i++ {
+			m.Put(Int((i*37+run*11)%100), Int(i))
+		}
+		var keys []Int
+		err := m.ForEachSorted(less, func(k, v Int) error {
+			keys = append(keys, k)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if !slices.IsSorted(keys) || len(keys) != 100 {
+			t.Errorf("expected 100 sorted keys, got %v", keys)
+		}
+		if run == 0 {
+			first = keys
+		} else if !slices.Equal(keys, first) {
+			t.Errorf("expected run %d to match the first run, got %v", run, keys)
+		}
+	}
+	var keys []Int
+	err := intMap(3, 30, 1, 10, 2, 20).ForEachSorted(less, func(k, v Int) error {
+		keys = append(keys, k)
+		if k == 2 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil || !slices.Equal(keys, []Int{1, 2}) {
+		t.Errorf("expected [1 2] and nil error, got %v and %v", keys, err)
+	}
+}
+
+func TestMapForEachParallel(t *testing.T) {
+	m := Map[Int, Int]{}
+	for i := 1; i <= 1000; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	for _, concurrency := range []int{1, 3, 8, 10000} {
+		var sum atomic.Int64
+		err := m.ForEachParallel(concurrency, func(k, v Int) error {
+			sum.Add(int64(v))
+			return nil
+		})
+		if err != nil || sum.Load() != 500500 {
+			t.Errorf("concurrency %d: expected sum 500500 and nil error, got %d and %v", concurrency, sum.Load(), err)
+		}
+	}
+	errBoom := errors.New("boom")
+	err := m.ForEachParallel(4, func(k, v Int) error {
+		if k == 500 {
+			return errBoom
+		}
+		return nil
+	})
+	if err != errBoom {
+		t.Errorf("expected errBoom, got %v", err)
+	}
+	err = m.ForEachParallel(4, func(k, v Int) error {
+		return ErrStopIteration
+	})
+	if err != nil {
+		t.Errorf("expected nil error after ErrStopIteration, got %v", err)
+	}
+	var empty Map[Int, Int]
+	if err := empty.ForEachParallel(4, func(k, v Int) error { return errBoom }); err != nil {
+		t.Errorf("expected nil error for empty map, got %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for zero concurrency")
+		}
+	}()
+	m.ForEachParallel(0, func(k, v Int) error { return nil })
+}
+
+func TestMapDrain(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	entries := m.Drain()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	if expected := []Entry[Int, Int]{{1, 10}, {2, 20}, {3, 30}}; !slices.Equal(entries, expected) {
+		t.Errorf("expected %v, got %v", expected, entries)
+	}
+	if m.Size() != 0 || !m.Equals(intMap()) {
+		t.Errorf("expected drained map to be empty, got %v", m)
+	}
+	m.Put(4, 40)
+	if !m.Equals(intMap(4, 40)) {
+		t.Errorf("expected drained map to be reusable, got %v", m)
+	}
+	if entries := (&Map[Int, Int]{}).Drain(); len(entries) != 0 {
+		t.Errorf("expected no entries, got %v", entries)
+	}
+}
+
+func TestMapContainsValue(t *testing.T) {
+	m := intMap(1, 10, 2, 20)
+	tests := []struct {
+		v  Int
+		ok bool
+	}{
+		{10, true},
+		{20, true},
+		{30, false},
+		{1, false},
+	}
+	for _, test := range tests {
+		if ok := m.ContainsValue(test.v, func(a, b Int) bool { return a == b }); ok != test.ok {
+			t.Errorf("expected ContainsValue(%d) to return %v, got %v", test.v, test.ok, ok)
+		}
+		if ok := ContainsValueComparable(m, test.v); ok != test.ok {
+			t.Errorf("expected ContainsValueComparable(%d) to return %v, got %v", test.v, test.ok, ok)
+		}
+	}
+	if intMap().ContainsValue(0, func(a, b Int) bool { return true }) {
+		t.Errorf("expected empty map not to contain any value")
+	}
+}
+
+// probeDistances returns the mean and maximum distance of the entries of m from their home slots.
+func probeDistances[K Comparable[K], V any](m *Map[K, V]) (float64, int) {
+	mask := uint64(len(m.entries) - 1)
+	total, longest := 0, 0
+	for i, entry := range m.entries {
+		if entry.hash1 != 0 {
+			d := int((uint64(i) - m.home(entry.hash1)) & mask)
+			total += d
+			longest = max(longest, d)
+		}
+	}
+	if m.size == 0 {
+		return 0, 0
+	}
+	return float64(total) / float64(m.size), longest
+}
+
+func TestMapRobinHood(t *testing.T) {
+	testRobinHood(t, NewMap[Int, Int](0, WithRobinHoodProbing[Int, Int](), WithLoadFactor[Int, Int](0.9, 0.2)))
+}
+
+func testRobinHood(t *testing.T, m *Map[Int, Int]) {
+	native := map[Int]Int{}
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 20000; i++ {
+		k := Int(r.IntN(2000))
+		switch r.IntN(3) {
+		case 0, 1:
+			m.Put(k, Int(i))
+			native[k] = Int(i)
+		case 2:
+			m.Remove(k)
+			delete(native, k)
+		}
+	}
+	if m.Size() != len(native) {
+		t.Fatalf("expected size %d, got %d", len(native), m.Size())
+	}
+	for k, v := range native {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Errorf("expected %d for key %d, got %d, %v", v, k, got, ok)
+		}
+	}
+	// Each entry is at most one slot further from home than the entry before it.
+	mask := uint64(len(m.entries) - 1)
+	for i, entry := range m.entries {
+		if entry.hash1 == 0 {
+			continue
+		}
+		prev := m.entries[(uint64(i)-1)&mask]
+		d := (uint64(i) - m.home(entry.hash1)) & mask
+		if d > 0 && (prev.hash1 == 0 || d > (uint64(i)-1-m.home(prev.hash1))&mask+1) {
+			t.Fatalf("expected Robin Hood invariant to hold at slot %d", i)
+		}
+	}
+	if c := m.Copy(); !c.robinHood || !c.Equals(m) {
+		t.Errorf("expected copy to keep Robin Hood probing")
+	}
+	v := m.Compute(5000, func(k, v Int, ok bool) Int {
+		if ok || v != 0 {
+			t.Errorf("expected Compute on a missing key to see the zero value, got %d, %v", v, ok)
+		}
+		return 1
+	})
+	if v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+}
+
+func TestMapSeed(t *testing.T) {
+	s := maphash.MakeSeed()
+	a := NewMap[Int, Int](0, WithSeed[Int, Int](s))
+	b := NewMap[Int, Int](0, WithSeed[Int, Int](s))
+	c := NewMap[Int, Int](0, WithRandomSeed[Int, Int]())
+	plain := Map[Int, Int]{}
+	for i := 0; i < 100; i++ {
+		for _, m := range []*Map[Int, Int]{a, b, c, &plain} {
+			m.Put(Int(i), Int(i))
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if v, ok := c.Get(Int(i)); !ok || v != Int(i) {
+			t.Errorf("expected %d, got %d, %v", i, v, ok)
+		}
+	}
+	if !slices.Equal(a.entries, b.entries) {
+		t.Errorf("expected maps with the same seed to have the same layout")
+	}
+	if slices.Equal(a.entries, plain.entries) {
+		t.Errorf("expected seeded map to have a different layout than an unseeded one")
+	}
+	if !a.Equals(c) || !c.Equals(&plain) || a.Hash() != plain.Hash() {
+		t.Errorf("expected maps with different seeds to be equal")
+	}
+	merged := Merge(c, intMap(100, 100), nil)
+	if v, ok := merged.Get(100); !ok || v != 100 || merged.Size() != 101 {
+		t.Errorf("expected merge across seeds to work, got %v", merged)
+	}
+	if cp := c.Copy(); cp.salt != c.salt || !cp.Equals(c) {
+		t.Errorf("expected copy to keep the seed")
+	}
+	testRobinHood(t, NewMap[Int, Int](0, WithRobinHoodProbing[Int, Int](), WithRandomSeed[Int, Int]()))
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		items []Int
+		r     *Map[Int, Int]
+	}{
+		{[]Int{1, 2, 2, 3, 3, 3}, intMap(1, 1, 2, 2, 3, 3)},
+		{[]Int{5}, intMap(5, 1)},
+		{nil, intMap()},
+	}
+	for _, test := range tests {
+		if r := Histogram(test.items); !r.Equals(test.r) {
+			t.Errorf("expected histogram of %v to be %v, got %v", test.items, test.r, r)
+		}
+	}
+	words := []string{"apple", "avocado", "banana", "cherry", "cranberry", "citrus"}
+	r := HistogramBy(words, func(w string) String { return String(w[:1]) })
+	for k, n := range map[String]Int{"a": 2, "b": 1, "c": 3} {
+		if v, _ := r.Get(k); v != n {
+			t.Errorf("expected %d words starting with %s, got %d", n, k, v)
+		}
+	}
+}
+
+func TestMapMergeInPlace(t *testing.T) {
+	sum := func(k, a, b Int) Int { return a + b }
+	tests := []struct {
+		a, b    *Map[Int, Int]
+		resolve func(k, a, b Int) Int
+		r       *Map[Int, Int]
+	}{
+		{intMap(1, 10, 2, 20), intMap(2, 21, 3, 30), nil, intMap(1, 10, 2, 21, 3, 30)},
+		{intMap(1, 10, 2, 20), intMap(2, 21, 3, 30), sum, intMap(1, 10, 2, 41, 3, 30)},
+		{intMap(), intMap(1, 10), nil, intMap(1, 10)},
+		{intMap(1, 10), intMap(), nil, intMap(1, 10)},
+	}
+	for _, test := range tests {
+		test.a.MergeInPlace(test.b, test.resolve)
+		if !test.a.Equals(test.r) {
+			t.Errorf("expected %v, got %v", test.r, test.a)
+		}
+	}
+	m := Map[Int, Int]{}
+	other := Map[Int, Int]{}
+	for i := 0; i < 100; i++ {
+		other.Put(Int(i), Int(i))
+	}
+	m.MergeInPlace(&other, nil)
+	if m.Capacity() != capacityFor(100, defaultMaxLoadFactor) || !m.Equals(&other) {
+		t.Errorf("expected a single allocation for 100 entries, got capacity %d", m.Capacity())
+	}
+}
+
+func TestMapSelectExcludeKeys(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30, 4, 40)
+	tests := []struct {
+		keys               []Int
+		selected, excluded *Map[Int, Int]
+	}{
+		{[]Int{2, 5}, intMap(2, 20), intMap(1, 10, 3, 30, 4, 40)},
+		{[]Int{1, 2, 3, 4, 5}, intMap(1, 10, 2, 20, 3, 30, 4, 40), intMap()},
+		{[]Int{5, 6, 7, 8, 9, 2}, intMap(2, 20), intMap(1, 10, 3, 30, 4, 40)},
+		{nil, intMap(), m},
+	}
+	for _, test := range tests {
+		if r := m.SelectKeys(test.keys...); !r.Equals(test.selected) {
+			t.Errorf("expected SelectKeys(%v) to be %v, got %v", test.keys, test.selected, r)
+		}
+		if r := m.ExcludeKeys(test.keys...); !r.Equals(test.excluded) {
+			t.Errorf("expected ExcludeKeys(%v) to be %v, got %v", test.keys, test.excluded, r)
+		}
+		s := SetFromSlice(test.keys)
+		if r := m.SelectKeysSet(s); !r.Equals(test.selected) {
+			t.Errorf("expected SelectKeysSet(%v) to be %v, got %v", s, test.selected, r)
+		}
+		if r := m.ExcludeKeysSet(s); !r.Equals(test.excluded) {
+			t.Errorf("expected ExcludeKeysSet(%v) to be %v, got %v", s, test.excluded, r)
+		}
+	}
+	if m.Size() != 4 {
+		t.Errorf("expected original map to be unchanged, got %v", m)
+	}
+	if r := intMap().SelectKeys(1); r.Size() != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+}
+
+func TestMapIntersectSubtract(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30, 4, 40)
+	tests := []struct {
+		other                   *Map[Int, Int]
+		intersected, subtracted *Map[Int, Int]
+	}{
+		{intMap(2, 0, 5, 0), intMap(2, 20), intMap(1, 10, 3, 30, 4, 40)},
+		{intMap(1, 0, 2, 0, 3, 0, 4, 0, 5, 0), m, intMap()},
+		{intMap(5, 0, 6, 0, 7, 0, 8, 0, 9, 0, 2, 0), intMap(2, 20), intMap(1, 10, 3, 30, 4, 40)},
+		{intMap(), intMap(), m},
+	}
+	for _, test := range tests {
+		if r := m.Intersect(test.other); !r.Equals(test.intersected) {
+			t.Errorf("expected Intersect(%v) to be %v, got %v", test.other, test.intersected, r)
+		}
+		if r := m.Subtract(test.other); !r.Equals(test.subtracted) {
+			t.Errorf("expected Subtract(%v) to be %v, got %v", test.other, test.subtracted, r)
+		}
+	}
+	if m.Size() != 4 {
+		t.Errorf("expected original map to be unchanged, got %v", m)
+	}
+	if r := intMap().Subtract(m); r.Size() != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+}
+
+func TestMapReplaceAll(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	c := m.Capacity()
+	m.ReplaceAll(func(k, v Int) Int { return v + k })
+	if r := intMap(1, 11, 2, 22, 3, 33); !m.Equals(r) {
+		t.Errorf("expected %v, got %v", r, m)
+	}
+	if m.Capacity() != c {
+		t.Errorf("expected capacity %d to be unchanged, got %d", c, m.Capacity())
+	}
+	e := Map[Int, Int]{}
+	e.ReplaceAll(func(k, v Int) Int { t.Errorf("expected no calls on an empty map"); return v })
+}
+
+func TestFlatMap(t *testing.T) {
+	m := intMap(0, 0, 1, 10, 2, 20)
+	// Key k expands to k entries, so 0 yields none, 1 yields one and 2 yields two.
+	r := FlatMap(m, func(k, v Int) []Entry[String, Int] {
+		var entries []Entry[String, Int]
+		for i := Int(0); i < k; i++ {
+			entries = append(entries, Entry[String, Int]{String(fmt.Sprint(k, "-", i)), v + i})
+		}
+		return entries
+	})
+	expected := map[String]Int{"1-0": 10, "2-0": 20, "2-1": 21}
+	if r.Size() != len(expected) {
+		t.Errorf("expected %d entries, got %v", len(expected), r)
+	}
+	for k, v := range expected {
+		if got, ok := r.Get(k); !ok || got != v {
+			t.Errorf("expected %d for key %s, got %d, %v", v, k, got, ok)
+		}
+	}
+	last := FlatMap(intMap(1, 10), func(k, v Int) []Entry[Int, Int] {
+		return []Entry[Int, Int]{{0, 1}, {0, 2}}
+	})
+	if !last.Equals(intMap(0, 2)) {
+		t.Errorf("expected last entry to win, got %v", last)
+	}
+}
+
+func TestZip(t *testing.T) {
+	names := Map[Int, String]{}
+	names.Put(1, "alice")
+	names.Put(2, "bob")
+	names.Put(3, "carol")
+	ages := intMap(2, 30, 3, 40, 4, 50)
+	joined := Zip(&names, ages)
+	if joined.Size() != 2 {
+		t.Errorf("expected inner join of 2 keys, got %v", joined)
+	}
+	if p, ok := joined.Get(2); !ok || p != (Zipped[String, Int]{"bob", 30}) {
+		t.Errorf("expected (bob, 30), got %v, %v", p, ok)
+	}
+	if _, ok := joined.Get(1); ok {
+		t.Errorf("expected key 1, missing from ages, to be left out")
+	}
+	if r := Zip(intMap(), ages); r.Size() != 0 {
+		t.Errorf("expected empty join, got %v", r)
+	}
+	// Values need not implement Comparable.
+	plain := Map[Int, []string]{}
+	plain.Put(2, []string{"x"})
+	if z := Zip(ages, &plain); z.MustGet(2).First != 30 || z.MustGet(2).Second[0] != "x" {
+		t.Errorf("expected (30, [x]), got %v", z.MustGet(2))
+	}
+}
+
+func TestZipAll(t *testing.T) {
+	a := intMap(1, 10, 2, 20)
+	b := Map[Int, String]{}
+	b.Put(2, "two")
+	b.Put(3, "three")
+	r := ZipAll(a, &b)
+	if r.Size() != 3 {
+		t.Errorf("expected 3 keys, got %d", r.Size())
+	}
+	if j, _ := r.Get(1); j.A == nil || *j.A != 10 || j.B != nil {
+		t.Errorf("expected (10, nil) for key 1, got %v", j)
+	}
+	if j, _ := r.Get(2); j.A == nil || *j.A != 20 || j.B == nil || *j.B != "two" {
+		t.Errorf("expected (20, two) for key 2, got %v", j)
+	}
+	if j, _ := r.Get(3); j.A != nil || j.B == nil || *j.B != "three" {
+		t.Errorf("expected (nil, three) for key 3, got %v", j)
+	}
+}
+
+// collidingKey only uses the low bits of its hash, like keys crafted against a known seed.
+type collidingKey int
+
+func (k collidingKey) Hash() uint64 {
+	return uint64(k) << 20
+}
+
+func (k collidingKey) Equals(other collidingKey) bool {
+	return k == other
+}
+
+// sameHashKey has the same hash for every key, which no seed can spread out.
+type sameHashKey int
+
+func (k sameHashKey) Hash() uint64 {
+	return 1
+}
+
+func (k sameHashKey) Equals(other sameHashKey) bool {
+	return k == other
+}
+
+func TestMapSeedCollisions(t *testing.T) {
+	spread := NewMap[collidingKey, Int](0, WithRandomSeed[collidingKey, Int]())
+	same := NewMap[sameHashKey, Int](0, WithRandomSeed[sameHashKey, Int]())
+	for i := 0; i < 200; i++ {
+		spread.Put(collidingKey(i), Int(i))
+		same.Put(sameHashKey(i), Int(i))
+	}
+	if l := spread.MaxProbeLength(); l > 20 {
+		t.Errorf("expected the seed to spread keys sharing a home slot, got longest probe %d", l)
+	}
+	// This is documented on WithSeed: full hash collisions are not helped by a seed.
+	if l := same.MaxProbeLength(); l != 200 {
+		t.Errorf("expected keys with equal hashes to share one probe sequence, got longest probe %d", l)
+	}
+}
+
+func TestMapRehash(t *testing.T) {
+	m := Map[collidingKey, Int]{}
+	for i := 0; i < 200; i++ {
+		m.Put(collidingKey(i), Int(i))
+	}
+	before := m.MaxProbeLength()
+	m.Rehash()
+	if m.salt == 0 {
+		t.Errorf("expected Rehash to set a seed")
+	}
+	if after := m.MaxProbeLength(); after >= before {
+		t.Errorf("expected Rehash to shorten the longest probe sequence %d, got %d", before, after)
+	}
+	for i := 0; i < 200; i++ {
+		if v, ok := m.Get(collidingKey(i)); !ok || v != Int(i) {
+			t.Errorf("expected %d, got %d, %v", i, v, ok)
+		}
+	}
+	e := Map[Int, Int]{}
+	e.Rehash()
+	e.Put(1, 1)
+	if !e.Equals(intMap(1, 1)) {
+		t.Errorf("expected rehashed empty map to work, got %v", &e)
+	}
+}
+
+func TestMapBatchGet(t *testing.T) {
+	m := intMap(1, 10, 2, 20, 3, 30)
+	values, found := m.BatchGet([]Int{3, 4, 1, 3})
+	if !slices.Equal(values, []Int{30, 0, 10, 30}) || !slices.Equal(found, []bool{true, false, true, true}) {
+		t.Errorf("expected [30 0 10 30] and [true false true true], got %v and %v", values, found)
+	}
+	values, found = intMap().BatchGet([]Int{1})
+	if !slices.Equal(values, []Int{0}) || !slices.Equal(found, []bool{false}) {
+		t.Errorf("expected [0] and [false], got %v and %v", values, found)
+	}
+	if values, found := m.BatchGet(nil); len(values) != 0 || len(found) != 0 {
+		t.Errorf("expected empty results, got %v and %v", values, found)
+	}
+}
+
+func TestMapBatchPutRemove(t *testing.T) {
+	m := Map[Int, Int]{}
+	entries := make([]Entry[Int, Int], 1000)
+	keys := make([]Int, 1000)
+	for i := range entries {
+		entries[i] = Entry[Int, Int]{Int(i), Int(i)}
+		keys[i] = Int(i)
+	}
+	m.BatchPut(entries)
+	if m.Size() != 1000 || m.Capacity() != capacityFor(1000, defaultMaxLoadFactor) {
+		t.Errorf("expected 1000 entries in a single allocation, got size %d, capacity %d", m.Size(), m.Capacity())
+	}
+	values, _ := m.BatchGet(keys[:3])
+	if !slices.Equal(values, []Int{0, 1, 2}) {
+		t.Errorf("expected [0 1 2], got %v", values)
+	}
+	m.BatchRemove(keys[1:])
+	if !m.Equals(intMap(0, 0)) || m.Capacity() != initialCapacity {
+		t.Errorf("expected {0:0} at initial capacity, got %v with capacity %d", &m, m.Capacity())
+	}
+}
+
+func TestMapStats(t *testing.T) {
+	if stats := (&Map[Int, Int]{}).Stats(); stats != (MapStats{}) {
+		t.Errorf("expected zero stats for the zero map, got %+v", stats)
+	}
+	// All collidingKeys below 16 share home slot 0, so they fill slots 0 to n-1.
+	m := Map[collidingKey, Int]{}
+	for i := 0; i < 5; i++ {
+		m.Put(collidingKey(i), Int(i))
+	}
+	expected := MapStats{Size: 5, Capacity: 16, LoadFactor: 5.0 / 16, MaxProbeLength: 5, MeanProbeLength: 2, EmptySlots: 11}
+	if stats := m.Stats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+	m.Remove(0)
+	expected = MapStats{Size: 4, Capacity: 16, LoadFactor: 4.0 / 16, MaxProbeLength: 4, MeanProbeLength: 1.5, EmptySlots: 12}
+	if stats := m.Stats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestEqualKeysAndValues(t *testing.T) {
+	tests := []struct {
+		a, b         *Map[Int, Int]
+		keys, values bool
+	}{
+		{intMap(), intMap(), true, true},
+		{intMap(1, 10, 2, 20), intMap(2, 20, 1, 10), true, true},
+		{intMap(1, 10, 2, 20), intMap(1, 10, 2, 21), true, false},
+		{intMap(1, 10, 2, 20), intMap(1, 10, 3, 30), false, true},
+		{intMap(1, 10, 2, 20), intMap(1, 11, 3, 30), false, false},
+		{intMap(1, 10), intMap(1, 10, 2, 20), false, false},
+		// Disjoint maps of the same size have no common keys whose values could differ.
+		{intMap(1, 10, 2, 20), intMap(3, 30, 4, 40), false, true},
+	}
+	for i, test := range tests {
+		if got := EqualKeys(test.a, test.b); got != test.keys {
+			t.Errorf("%d: expected EqualKeys %v, got %v", i, test.keys, got)
+		}
+		if got := EqualValues(test.a, test.b); got != test.values {
+			t.Errorf("%d: expected EqualValues %v, got %v", i, test.values, got)
+		}
+	}
+}
+
 // The benchmarks below are meant to check whether the overhead of the Map type is acceptable.
 // If we're within an order of magnitude of the native map, we're good.
 // We are not benchmarking deletes because the native map doesn't shrink when deleting elements and our map does.
@@ -147,3 +1658,256 @@ func BenchmarkBigKeyMap(b *testing.B) {
 		}
 	}
 }
+
+func groupByItems() []string {
+	items := make([]string, 1000)
+	for i := range items {
+		items[i] = fmt.Sprint(i)
+	}
+	return items
+}
+
+func BenchmarkNativeGroupBy(b *testing.B) {
+	items := groupByItems()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := make(map[string][]string)
+		for _, item := range items {
+			k := item[len(item)-1:]
+			m[k] = append(m[k], item)
+		}
+	}
+}
+
+func BenchmarkGroupBy(b *testing.B) {
+	items := groupByItems()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GroupBy(items, func(item string) String { return String(item[len(item)-1:]) })
+	}
+}
+
+func partitionMap() *Map[Int, Int] {
+	m := NewMap[Int, Int](1000)
+	for i := 0; i < 1000; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	return m
+}
+
+func BenchmarkMapPartition(b *testing.B) {
+	m := partitionMap()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Partition(func(k, v Int) bool { return k%2 == 0 })
+	}
+}
+
+func BenchmarkMapFilterTwice(b *testing.B) {
+	m := partitionMap()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Filter(func(k, v Int) bool { return k%2 == 0 })
+		m.Filter(func(k, v Int) bool { return k%2 != 0 })
+	}
+}
+
+// BenchmarkMapContainsValue scans all values, which is O(n);
+// compare with BenchmarkMapValueIndex, which keeps a second map from value to key.
+func BenchmarkMapContainsValue(b *testing.B) {
+	m := partitionMap()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !ContainsValueComparable(m, Int(i%1000)) {
+			b.Fatal("expected to find value")
+		}
+	}
+}
+
+func BenchmarkMapValueIndex(b *testing.B) {
+	index := InvertMap(partitionMap())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := index.Get(Int(i % 1000)); !ok {
+			b.Fatal("expected to find value")
+		}
+	}
+}
+
+func benchmarkProbing(b *testing.B, maxLoad float64, opts ...MapOption[Int, Int]) {
+	opts = append(opts, WithLoadFactor[Int, Int](maxLoad, maxLoad/4))
+	n := int(maxLoad * 4096)
+	var m *Map[Int, Int]
+	for i := 0; i < b.N; i++ {
+		m = NewMap[Int, Int](n, opts...)
+		for j := 0; j < n; j++ {
+			m.Put(Int(j), Int(j))
+		}
+		for j := 0; j < n; j++ {
+			if _, ok := m.Get(Int(j)); !ok {
+				b.Fatal("expected to find key")
+			}
+		}
+	}
+	mean, longest := probeDistances(m)
+	b.ReportMetric(mean, "mean-probe")
+	b.ReportMetric(float64(longest), "max-probe")
+}
+
+func BenchmarkLinearProbing70(b *testing.B) {
+	benchmarkProbing(b, 0.7)
+}
+
+func BenchmarkRobinHoodProbing70(b *testing.B) {
+	benchmarkProbing(b, 0.7, WithRobinHoodProbing[Int, Int]())
+}
+
+func BenchmarkLinearProbing85(b *testing.B) {
+	benchmarkProbing(b, 0.85)
+}
+
+func BenchmarkRobinHoodProbing85(b *testing.B) {
+	benchmarkProbing(b, 0.85, WithRobinHoodProbing[Int, Int]())
+}
+
+func BenchmarkNativeHistogram(b *testing.B) {
+	items := groupByItems()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := make(map[string]int)
+		for _, item := range items {
+			m[item[len(item)-1:]]++
+		}
+	}
+}
+
+func BenchmarkHistogram(b *testing.B) {
+	items := groupByItems()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HistogramBy(items, func(item string) String { return String(item[len(item)-1:]) })
+	}
+}
+
+func zipMaps() (*Map[Int, Int], *Map[Int, Int]) {
+	a, b := NewMap[Int, Int](1000), NewMap[Int, Int](1000)
+	for i := 0; i < 1000; i++ {
+		a.Put(Int(i), Int(i))
+		b.Put(Int(i+500), Int(i))
+	}
+	return a, b
+}
+
+func BenchmarkZip(b *testing.B) {
+	x, y := zipMaps()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Zip(x, y)
+	}
+}
+
+func BenchmarkZipForEachGet(b *testing.B) {
+	x, y := zipMaps()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := Map[Int, Pair[Int, Int]]{}
+		x.ForEach(func(k, v Int) error {
+			if w, ok := y.Get(k); ok {
+				r.Put(k, NewPair(v, w))
+			}
+			return nil
+		})
+	}
+}
+
+func batchGetKeys() (*Map[Int, Int], []Int) {
+	m := NewMap[Int, Int](100000)
+	for i := 0; i < 100000; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	keys := make([]Int, 1000)
+	for i := range keys {
+		keys[i] = Int(i * 7919 % 100000)
+	}
+	return m, keys
+}
+
+func BenchmarkMapBatchGet(b *testing.B) {
+	m, keys := batchGetKeys()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.BatchGet(keys)
+	}
+}
+
+func BenchmarkMapGetLoop(b *testing.B) {
+	m, keys := batchGetKeys()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		values := make([]Int, len(keys))
+		found := make([]bool, len(keys))
+		for j, k := range keys {
+			values[j], found[j] = m.Get(k)
+		}
+	}
+}
+
+// spin is a CPU-bound callback for the ForEachParallel benchmarks.
+func spin(k, v Int) error {
+	x := uint64(v)
+	for i := 0; i < 1000; i++ {
+		x = x*6364136223846793005 + 1442695040888963407
+	}
+	if x == 0 {
+		return errors.New("unreachable")
+	}
+	return nil
+}
+
+func benchmarkForEachParallel(b *testing.B, concurrency int) {
+	m := Map[Int, Int]{}
+	for i := 0; i < 10000; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.ForEachParallel(concurrency, spin)
+	}
+}
+
+func BenchmarkForEachParallel1(b *testing.B) {
+	benchmarkForEachParallel(b, 1)
+}
+
+func BenchmarkForEachParallel2(b *testing.B) {
+	benchmarkForEachParallel(b, 2)
+}
+
+func BenchmarkForEachParallel4(b *testing.B) {
+	benchmarkForEachParallel(b, 4)
+}
+
+func BenchmarkForEachParallel8(b *testing.B) {
+	benchmarkForEachParallel(b, 8)
+}
+
+func benchmarkMapDrain(b *testing.B, n int) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		m := NewMap[Int, Int](n)
+		for j := 0; j < n; j++ {
+			m.Put(Int(j), Int(j))
+		}
+		b.StartTimer()
+		for _, _, ok := m.Pop(); ok; _, _, ok = m.Pop() {
+		}
+	}
+}
+
+func BenchmarkMapDrain10k(b *testing.B) {
+	benchmarkMapDrain(b, 10000)
+}
+
+func BenchmarkMapDrain80k(b *testing.B) {
+	benchmarkMapDrain(b, 80000)
+}
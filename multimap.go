@@ -0,0 +1,83 @@
+package hashmap
+
+import "slices"
+
+// Multimap is a map from keys to sets of values.
+// It is not thread-safe.
+// The zero value is an empty multimap ready to use.
+type Multimap[K Comparable[K], V Comparable[V]] struct {
+	sets Map[K, *Set[V]]
+	size int
+}
+
+// Size returns the total number of values in the multimap.
+func (m *Multimap[K, V]) Size() int {
+	return m.size
+}
+
+// Add associates the given value with the given key.
+func (m *Multimap[K, V]) Add(key K, val V) {
+	s := m.sets.ComputeIfAbsent(key, func(K) *Set[V] { return new(Set[V]) })
+	n := s.Size()
+	s.Add(val)
+	m.size += s.Size() - n
+}
+
+// Remove removes the association of the given value with the given key.
+// The key is removed when its last value is removed.
+func (m *Multimap[K, V]) Remove(key K, val V) {
+	s, ok := m.sets.Get(key)
+	if !ok {
+		return
+	}
+	n := s.Size()
+	s.Remove(val)
+	m.size -= n - s.Size()
+	if s.Size() == 0 {
+		m.sets.Remove(key)
+	}
+}
+
+// RemoveAll removes the given key and all its values.
+func (m *Multimap[K, V]) RemoveAll(key K) {
+	if s, ok := m.sets.GetAndRemove(key); ok {
+		m.size -= s.Size()
+	}
+}
+
+// Get returns a copy of the set of values associated with the given key.
+// The set is empty if the key is not in the multimap.
+func (m *Multimap[K, V]) Get(key K) *Set[V] {
+	if s, ok := m.sets.Get(key); ok {
+		return s.Copy()
+	}
+	return new(Set[V])
+}
+
+// Contains returns true if the given value is associated with the given key.
+func (m *Multimap[K, V]) Contains(key K, val V) bool {
+	s, ok := m.sets.Get(key)
+	return ok && s.Contains(val)
+}
+
+// Keys returns a slice of all keys in the multimap in unspecified order.
+func (m *Multimap[K, V]) Keys() []K {
+	return slices.Collect(m.sets.Keys())
+}
+
+// ForEach calls the given function for each key/value pair in the multimap.
+// If f returns an error, iteration stops and ForEach returns that error,
+// unless it is ErrStopIteration, in which case ForEach returns nil.
+func (m *Multimap[K, V]) ForEach(f func(K, V) error) error {
+	for k, s := range m.sets.All() {
+		for v := range s.All() {
+			if err := f(k, v); err != nil {
+				if err == ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package hashmap
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestMultimap(t *testing.T) {
+	m := Multimap[String, Int]{}
+	m.Add("a", 1)
+	m.Add("a", 2)
+	m.Add("a", 2)
+	m.Add("b", 3)
+	if m.Size() != 3 {
+		t.Errorf("expected size 3, got %d", m.Size())
+	}
+	if !m.Get("a").Equals(intSet(1, 2)) {
+		t.Errorf("expected {1 2} for key a, got %v", m.Get("a").ToSlice())
+	}
+	if m.Get("c").Size() != 0 {
+		t.Errorf("expected empty set for missing key")
+	}
+	if !m.Contains("a", 2) || m.Contains("b", 2) || m.Contains("c", 1) {
+		t.Errorf("unexpected Contains result")
+	}
+
+	m.Get("a").Add(5)
+	if m.Contains("a", 5) {
+		t.Errorf("expected Get to return a copy")
+	}
+
+	m.Remove("b", 3)
+	m.Remove("b", 3)
+	m.Remove("c", 1)
+	if keys := m.Keys(); !reflect.DeepEqual(keys, []String{"a"}) {
+		t.Errorf("expected key b to be removed with its last value, got %v", keys)
+	}
+	if m.Size() != 2 {
+		t.Errorf("expected size 2, got %d", m.Size())
+	}
+
+	m.Add("b", 4)
+	n := 0
+	m.ForEach(func(k String, v Int) error {
+		n++
+		return nil
+	})
+	if n != 3 {
+		t.Errorf("expected 3 pairs, got %d", n)
+	}
+
+	m.RemoveAll("a")
+	if keys := slices.Sorted(slices.Values(m.Keys())); !reflect.DeepEqual(keys, []String{"b"}) {
+		t.Errorf("expected [b], got %v", keys)
+	}
+	if m.Size() != 1 {
+		t.Errorf("expected size 1, got %d", m.Size())
+	}
+}
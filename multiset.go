@@ -0,0 +1,100 @@
+package hashmap
+
+// Multiset is a set that counts how many times each element was added.
+// It is not thread-safe.
+// The zero value is an empty multiset ready to use.
+type Multiset[K Comparable[K]] struct {
+	counts Map[K, int]
+	size   int
+}
+
+// Size returns the sum of the counts of all elements.
+func (s *Multiset[K]) Size() int {
+	return s.size
+}
+
+// Distinct returns the number of distinct elements.
+func (s *Multiset[K]) Distinct() int {
+	return s.counts.Size()
+}
+
+// Count returns the number of times the given key is in the multiset.
+func (s *Multiset[K]) Count(key K) int {
+	n, _ := s.counts.Get(key)
+	return n
+}
+
+// Contains returns true if the given key is in the multiset at least once.
+func (s *Multiset[K]) Contains(key K) bool {
+	return s.Count(key) > 0
+}
+
+// Add adds the given key n times. It does nothing if n is not positive.
+func (s *Multiset[K]) Add(key K, n int) {
+	if n <= 0 {
+		return
+	}
+	s.counts.Compute(key, func(k K, c int, ok bool) int {
+		return c + n
+	})
+	s.size += n
+}
+
+// Remove removes the given key n times.
+// If the key is in the multiset fewer than n times, it is removed completely.
+func (s *Multiset[K]) Remove(key K, n int) {
+	if n <= 0 {
+		return
+	}
+	c, ok := s.counts.Get(key)
+	if !ok {
+		return
+	}
+	if c <= n {
+		s.counts.Remove(key)
+		s.size -= c
+		return
+	}
+	s.counts.Put(key, c-n)
+	s.size -= n
+}
+
+// ForEach calls the given function for each distinct key and its count.
+// If f returns an error, iteration stops and ForEach returns that error,
+// unless it is ErrStopIteration, in which case ForEach returns nil.
+func (s *Multiset[K]) ForEach(f func(K, int) error) error {
+	return s.counts.ForEach(f)
+}
+
+// Union returns a new multiset where each key has the larger of its counts in both multisets.
+func (s *Multiset[K]) Union(t *Multiset[K]) *Multiset[K] {
+	r := &Multiset[K]{}
+	for k, c := range s.counts.All() {
+		r.Add(k, max(c, t.Count(k)))
+	}
+	for k, c := range t.counts.All() {
+		if !s.Contains(k) {
+			r.Add(k, c)
+		}
+	}
+	return r
+}
+
+// Intersection returns a new multiset where each key has the smaller of its counts in both multisets.
+func (s *Multiset[K]) Intersection(t *Multiset[K]) *Multiset[K] {
+	r := &Multiset[K]{}
+	for k, c := range s.counts.All() {
+		r.Add(k, min(c, t.Count(k)))
+	}
+	return r
+}
+
+// Difference returns a new multiset where each key has its count in the multiset
+// minus its count in the given multiset, or is absent if that is not positive.
+func (s *Multiset[K]) Difference(t *Multiset[K]) *Multiset[K] {
+	r := &Multiset[K]{}
+	for k, c := range s.counts.All() {
+		r.Add(k, c-t.Count(k))
+	}
+	return r
+}
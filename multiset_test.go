@@ -0,0 +1,77 @@
+package hashmap
+
+import "testing"
+
+func intMultiset(is ...int) *Multiset[Int] {
+	s := new(Multiset[Int])
+	for _, i := range is {
+		s.Add(Int(i), 1)
+	}
+	return s
+}
+
+func multisetEquals(a, b *Multiset[Int]) bool {
+	return a.Size() == b.Size() && a.counts.Equals(&b.counts)
+}
+
+func TestMultiset(t *testing.T) {
+	s := Multiset[Int]{}
+	s.Add(1, 3)
+	s.Add(2, 1)
+	s.Add(3, 0)
+	s.Add(3, -1)
+	if s.Size() != 4 || s.Distinct() != 2 {
+		t.Errorf("expected size 4 and 2 distinct, got %d and %d", s.Size(), s.Distinct())
+	}
+	if s.Count(1) != 3 || s.Count(3) != 0 {
+		t.Errorf("unexpected counts")
+	}
+	if !s.Contains(2) || s.Contains(3) {
+		t.Errorf("unexpected Contains result")
+	}
+
+	s.Remove(1, 2)
+	if s.Count(1) != 1 || s.Size() != 2 {
+		t.Errorf("expected count 1 and size 2, got %d and %d", s.Count(1), s.Size())
+	}
+	s.Remove(1, 5)
+	if s.Count(1) != 0 || s.Contains(1) || s.Size() != 1 || s.Distinct() != 1 {
+		t.Errorf("expected removing more than present to clamp to zero")
+	}
+	s.Remove(7, 1)
+	if s.Size() != 1 {
+		t.Errorf("expected removing absent key to do nothing")
+	}
+
+	total := 0
+	s.ForEach(func(k Int, n int) error {
+		total += n
+		return nil
+	})
+	if total != 1 {
+		t.Errorf("expected counts to sum to 1, got %d", total)
+	}
+}
+
+func TestMultisetOperations(t *testing.T) {
+	tests := []struct {
+		a, b               *Multiset[Int]
+		union, inter, diff *Multiset[Int]
+	}{
+		{intMultiset(1, 1, 2), intMultiset(1, 2, 2, 3), intMultiset(1, 1, 2, 2, 3), intMultiset(1, 2), intMultiset(1)},
+		{intMultiset(1, 2), intMultiset(), intMultiset(1, 2), intMultiset(), intMultiset(1, 2)},
+		{intMultiset(), intMultiset(1), intMultiset(1), intMultiset(), intMultiset()},
+		{intMultiset(1, 1), intMultiset(1, 1, 1), intMultiset(1, 1, 1), intMultiset(1, 1), intMultiset()},
+	}
+	for i, test := range tests {
+		if r := test.a.Union(test.b); !multisetEquals(r, test.union) {
+			t.Errorf("test %d: unexpected union", i)
+		}
+		if r := test.a.Intersection(test.b); !multisetEquals(r, test.inter) {
+			t.Errorf("test %d: unexpected intersection", i)
+		}
+		if r := test.a.Difference(test.b); !multisetEquals(r, test.diff) {
+			t.Errorf("test %d: unexpected difference", i)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package hashmap
+
+// ToNativeMap returns a native Go map with the key/value pairs of m,
+// with each key converted by conv. If conv maps several keys to the same
+// native key, one of their values is kept; which one is unspecified.
+func ToNativeMap[K Comparable[K], NK comparable, V any](m *Map[K, V], conv func(K) NK) map[NK]V {
+	r := make(map[NK]V, m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			r[conv(entry.key)] = entry.value
+		}
+	}
+	return r
+}
+
+// ToNativeStringMap returns a native Go map keyed by the strings underlying the keys of m,
+// such as those of String.
+func ToNativeStringMap[K interface {
+	Comparable[K]
+	~string
+}, V any](m *Map[K, V]) map[string]V {
+	return ToNativeMap(m, func(k K) string { return string(k) })
+}
+
+// ToNativeNumberMap returns a native Go map keyed by the numbers underlying the keys of m.
+// N is usually the underlying type of K, as in ToNativeNumberMap[int64](m) for a Map[Int64, V];
+// other numeric types are converted as by a Go conversion, which may merge keys.
+func ToNativeNumberMap[N Number, K interface {
+	Comparable[K]
+	Number
+}, V any](m *Map[K, V]) map[N]V {
+	return ToNativeMap(m, func(k K) N { return N(k) })
+}
+
+// ToNativeIntMap returns a native Go map keyed by the ints underlying the keys of m,
+// such as those of Int. It is ToNativeNumberMap[int].
+func ToNativeIntMap[K interface {
+	Comparable[K]
+	~int
+}, V any](m *Map[K, V]) map[int]V {
+	return ToNativeNumberMap[int](m)
+}
+
+// ToNativeSet returns a native Go set with the keys of s converted by conv.
+func ToNativeSet[K Comparable[K], NK comparable](s *Set[K], conv func(K) NK) map[NK]struct{} {
+	r := make(map[NK]struct{}, s.size)
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			r[conv(entry.key)] = struct{}{}
+		}
+	}
+	return r
+}
+
+// ToNativeStringSet returns a native Go set of the strings underlying the keys of s.
+func ToNativeStringSet[K interface {
+	Comparable[K]
+	~string
+}](s *Set[K]) map[string]struct{} {
+	return ToNativeSet(s, func(k K) string { return string(k) })
+}
+
+// ToNativeNumberSet returns a native Go set of the numbers underlying the keys of s,
+// converted to N as ToNativeNumberMap does.
+func ToNativeNumberSet[N Number, K interface {
+	Comparable[K]
+	Number
+}](s *Set[K]) map[N]struct{} {
+	return ToNativeSet(s, func(k K) N { return N(k) })
+}
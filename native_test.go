@@ -0,0 +1,48 @@
+package hashmap
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestToNativeMap(t *testing.T) {
+	m := intMap(1, 10, 2, 20, -2, 30)
+	abs := ToNativeMap(m, func(k Int) int { return max(int(k), -int(k)) })
+	if len(abs) != 2 || abs[1] != 10 {
+		t.Errorf("expected 2 native keys, got %v", abs)
+	}
+	if r := ToNativeIntMap(m); !maps.Equal(r, map[int]Int{1: 10, 2: 20, -2: 30}) {
+		t.Errorf("expected map[-2:30 1:10 2:20], got %v", r)
+	}
+	s := Map[String, Int]{}
+	s.Put("a", 1)
+	if r := ToNativeStringMap(&s); !maps.Equal(r, map[string]Int{"a": 1}) {
+		t.Errorf("expected map[a:1], got %v", r)
+	}
+	if r := ToNativeIntMap(intMap()); len(r) != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+	u := Map[Uint64, Int]{}
+	u.Put(1<<63, 1)
+	if r := ToNativeNumberMap[uint64](&u); !maps.Equal(r, map[uint64]Int{1 << 63: 1}) {
+		t.Errorf("expected map[9223372036854775808:1], got %v", r)
+	}
+	f := Map[Float64, String]{}
+	f.Put(1.5, "a")
+	if r := ToNativeNumberMap[float64](&f); !maps.Equal(r, map[float64]String{1.5: "a"}) {
+		t.Errorf("expected map[1.5:a], got %v", r)
+	}
+}
+
+func TestToNativeSet(t *testing.T) {
+	s := SetFromSlice([]String{"a", "b"})
+	if r := ToNativeStringSet(s); !maps.Equal(r, map[string]struct{}{"a": {}, "b": {}}) {
+		t.Errorf("expected {a b}, got %v", r)
+	}
+	if r := ToNativeSet(intSet(1, 2, 3), func(k Int) bool { return k%2 == 0 }); len(r) != 2 {
+		t.Errorf("expected {false true}, got %v", r)
+	}
+	if r := ToNativeNumberSet[int32](SetFromSlice([]Int32{-1, 2})); !maps.Equal(r, map[int32]struct{}{-1: {}, 2: {}}) {
+		t.Errorf("expected {-1 2}, got %v", r)
+	}
+}
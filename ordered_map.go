@@ -0,0 +1,101 @@
+package hashmap
+
+type orderedNode[K Comparable[K], V any] struct {
+	key        K
+	value      V
+	prev, next *orderedNode[K, V]
+}
+
+// OrderedMap is a hash map that remembers the order in which keys were inserted.
+// It is not thread-safe.
+// The zero value is an empty map ready to use.
+type OrderedMap[K Comparable[K], V any] struct {
+	nodes       Map[K, *orderedNode[K, V]]
+	front, back *orderedNode[K, V]
+}
+
+// Size returns the number of elements in the map.
+func (m *OrderedMap[K, V]) Size() int {
+	return m.nodes.Size()
+}
+
+// Get returns the value associated with the given key.
+// The second return value indicates if the key was found.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if n, ok := m.nodes.Get(key); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put adds the given key/value pair to the back of the map.
+// If the key already exists, the value is updated and the key keeps its position.
+func (m *OrderedMap[K, V]) Put(key K, value V) {
+	n := m.nodes.ComputeIfAbsent(key, func(key K) *orderedNode[K, V] {
+		n := &orderedNode[K, V]{key: key, prev: m.back}
+		if m.back != nil {
+			m.back.next = n
+		} else {
+			m.front = n
+		}
+		m.back = n
+		return n
+	})
+	n.value = value
+}
+
+// Remove removes the given key from the map.
+func (m *OrderedMap[K, V]) Remove(key K) {
+	n, ok := m.nodes.GetAndRemove(key)
+	if !ok {
+		return
+	}
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		m.front = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		m.back = n.prev
+	}
+}
+
+// Front returns the least recently inserted key/value pair.
+// The third return value is false if the map is empty.
+func (m *OrderedMap[K, V]) Front() (K, V, bool) {
+	if m.front == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return m.front.key, m.front.value, true
+}
+
+// Back returns the most recently inserted key/value pair.
+// The third return value is false if the map is empty.
+func (m *OrderedMap[K, V]) Back() (K, V, bool) {
+	if m.back == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return m.back.key, m.back.value, true
+}
+
+// ForEach calls the given function for each key/value pair in the map in insertion order.
+// If f returns an error, iteration stops and ForEach returns that error,
+// unless it is ErrStopIteration, in which case ForEach returns nil.
+func (m *OrderedMap[K, V]) ForEach(f func(K, V) error) error {
+	for n := m.front; n != nil; n = n.next {
+		if err := f(n.key, n.value); err != nil {
+			if err == ErrStopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
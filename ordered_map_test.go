@@ -0,0 +1,63 @@
+package hashmap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func orderedKeys(m *OrderedMap[Int, Int]) []Int {
+	var keys []Int
+	m.ForEach(func(k Int, v Int) error {
+		keys = append(keys, k)
+		return nil
+	})
+	return keys
+}
+
+func TestOrderedMap(t *testing.T) {
+	m := OrderedMap[Int, Int]{}
+	if _, _, ok := m.Front(); ok {
+		t.Errorf("expected no front in empty map")
+	}
+	for _, k := range []Int{5, 3, 9, 1, 7} {
+		m.Put(k, k*10)
+	}
+	if keys := orderedKeys(&m); !reflect.DeepEqual(keys, []Int{5, 3, 9, 1, 7}) {
+		t.Errorf("expected insertion order, got %v", keys)
+	}
+
+	m.Put(9, 99)
+	if keys := orderedKeys(&m); !reflect.DeepEqual(keys, []Int{5, 3, 9, 1, 7}) {
+		t.Errorf("expected update to keep order, got %v", keys)
+	}
+	if v, ok := m.Get(9); !ok || v != 99 {
+		t.Errorf("expected value 99, got %d", v)
+	}
+
+	m.Remove(5)
+	m.Remove(7)
+	m.Remove(9)
+	m.Remove(100)
+	if keys := orderedKeys(&m); !reflect.DeepEqual(keys, []Int{3, 1}) {
+		t.Errorf("expected [3 1], got %v", keys)
+	}
+	if k, v, ok := m.Front(); !ok || k != 3 || v != 30 {
+		t.Errorf("expected front (3, 30), got (%d, %d)", k, v)
+	}
+	if k, v, ok := m.Back(); !ok || k != 1 || v != 10 {
+		t.Errorf("expected back (1, 10), got (%d, %d)", k, v)
+	}
+	if m.Size() != 2 {
+		t.Errorf("expected size 2, got %d", m.Size())
+	}
+
+	m.Remove(3)
+	m.Remove(1)
+	if _, _, ok := m.Back(); ok {
+		t.Errorf("expected no back in emptied map")
+	}
+	m.Put(2, 20)
+	if keys := orderedKeys(&m); !reflect.DeepEqual(keys, []Int{2}) {
+		t.Errorf("expected [2], got %v", keys)
+	}
+}
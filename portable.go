@@ -0,0 +1,45 @@
+package hashmap
+
+import "encoding"
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// PortableHash returns the 64-bit FNV-1a hash of data.
+// Unlike Hash, it is the same in every process, so it can be stored or compared across runs.
+// It is not keyed, so it is NOT suitable for hash table bucketing of untrusted input:
+// colliding inputs are easy to construct.
+func PortableHash(data []byte) uint64 {
+	h := uint64(fnvOffset64)
+	for _, b := range data {
+		h ^= uint64(b)
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// PortableHasher is implemented by types that provide a hash that is the same in every process.
+type PortableHasher interface {
+	PortableHash() uint64
+}
+
+// PortableHashOf returns a hash of v that is the same in every process.
+// It uses v's PortableHash method if it has one, or else PortableHash of its
+// MarshalBinary encoding, as for Int and String. The second return value is false
+// if v supports neither or its encoding fails. Hash cannot be used as a fallback
+// because it depends on the per-process seed.
+func PortableHashOf(v any) (uint64, bool) {
+	switch v := v.(type) {
+	case PortableHasher:
+		return v.PortableHash(), true
+	case encoding.BinaryMarshaler:
+		data, err := v.MarshalBinary()
+		if err != nil {
+			return 0, false
+		}
+		return PortableHash(data), true
+	}
+	return 0, false
+}
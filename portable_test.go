@@ -0,0 +1,43 @@
+package hashmap
+
+import "testing"
+
+type portableKey int
+
+func (k portableKey) PortableHash() uint64 {
+	return uint64(k) * 31
+}
+
+func TestPortableHash(t *testing.T) {
+	tests := []struct {
+		data string
+		h    uint64
+	}{
+		// Reference values for 64-bit FNV-1a.
+		{"", 0xcbf29ce484222325},
+		{"a", 0xaf63dc4c8601ec8c},
+		{"foobar", 0x85944171f73967e8},
+	}
+	for _, test := range tests {
+		if h := PortableHash([]byte(test.data)); h != test.h {
+			t.Errorf("expected PortableHash(%q) to be %#x, got %#x", test.data, test.h, h)
+		}
+	}
+}
+
+func TestPortableHashOf(t *testing.T) {
+	if h, ok := PortableHashOf(portableKey(2)); !ok || h != 62 {
+		t.Errorf("expected PortableHash method to be used, got %d, %v", h, ok)
+	}
+	if h, ok := PortableHashOf(String("foobar")); !ok || h != 0x85944171f73967e8 {
+		t.Errorf("expected hash of the binary encoding, got %#x, %v", h, ok)
+	}
+	a, _ := PortableHashOf(Int(1))
+	b, _ := PortableHashOf(Int(2))
+	if a == b {
+		t.Errorf("expected different ints to hash differently")
+	}
+	if _, ok := PortableHashOf(Float64(1)); ok {
+		t.Errorf("expected Float64 to have no portable hash")
+	}
+}
@@ -1,5 +1,13 @@
 package hashmap
 
+import (
+	"hash/maphash"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
 type setEntry[K Comparable[K]] struct {
 	hash1 uint64
 	key   K
@@ -10,13 +18,54 @@ type setEntry[K Comparable[K]] struct {
 // The zero value is an empty set ready to use.
 // Set implements Comparable, so it can be used as a key in a Map or an element in a Set.
 type Set[K Comparable[K]] struct {
-	entries []setEntry[K]
-	size    int
-	hash    uint64
+	entries  []setEntry[K]
+	size     int
+	hash     uint64
+	salt     uint64
+	popIndex int // where Pop resumes its search for a key
 }
 
 var emptySetHash uint64 = Int(0).Hash()
 
+// SetOption configures a Set created by NewSet.
+type SetOption[K Comparable[K]] func(*Set[K])
+
+// WithSetSeed is the Set counterpart of WithSeed.
+func WithSetSeed[K Comparable[K]](seed maphash.Seed) SetOption[K] {
+	return func(s *Set[K]) {
+		s.salt = saltFor(seed)
+	}
+}
+
+// WithSetRandomSeed is the Set counterpart of WithRandomSeed.
+func WithSetRandomSeed[K Comparable[K]]() SetOption[K] {
+	return func(s *Set[K]) {
+		s.salt = saltFor(maphash.MakeSeed())
+	}
+}
+
+// NewSet returns a new set with room for at least capacity elements without resizing.
+// If capacity is not positive, the set starts out empty like the zero value.
+func NewSet[K Comparable[K]](capacity int, opts ...SetOption[K]) *Set[K] {
+	s := &Set[K]{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if capacity > 0 {
+		s.entries = make([]setEntry[K], capacityFor(capacity, defaultMaxLoadFactor))
+		s.hash = emptySetHash
+	}
+	return s
+}
+
+// home returns the index of the slot where probing for hash1 starts.
+func (s *Set[K]) home(hash1 uint64) uint64 {
+	if s.salt != 0 {
+		hash1 = salted(hash1, s.salt)
+	}
+	return hash1 & uint64(len(s.entries)-1)
+}
+
 func (s *Set[K]) init() {
 	s.entries = make([]setEntry[K], initialCapacity)
 	s.hash = emptySetHash
@@ -27,6 +76,57 @@ func (s *Set[K]) Size() int {
 	return s.size
 }
 
+// Capacity returns the length of the backing array of the set.
+func (s *Set[K]) Capacity() int {
+	return len(s.entries)
+}
+
+// LoadFactor returns the fraction of the backing array that is occupied.
+// It returns 0 for a set without a backing array.
+func (s *Set[K]) LoadFactor() float64 {
+	if len(s.entries) == 0 {
+		return 0
+	}
+	return float64(s.size) / float64(len(s.entries))
+}
+
+// IsEmpty returns true if the set has no elements.
+func (s *Set[K]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// Clear removes all elements from the set.
+// The backing array is reused if it is already at the initial capacity.
+func (s *Set[K]) Clear() {
+	if s.entries == nil {
+		return
+	}
+	if len(s.entries) == initialCapacity {
+		for i := range s.entries {
+			s.entries[i] = setEntry[K]{}
+		}
+		s.hash = emptySetHash
+	} else {
+		s.init()
+	}
+	s.size = 0
+}
+
+// Drain removes all elements from the set and returns them in unspecified order.
+// The backing array is handed off rather than cleared,
+// so the set is left without one, like the zero value.
+func (s *Set[K]) Drain() []K {
+	entries, size := s.entries, s.size
+	s.entries, s.size, s.hash = nil, 0, 0
+	keys := make([]K, 0, size)
+	for _, entry := range entries {
+		if entry.hash1 != 0 {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
 // Contains returns true if the set contains the given key.
 func (s *Set[K]) Contains(key K) bool {
 	if s.size == 0 {
@@ -37,7 +137,7 @@ func (s *Set[K]) Contains(key K) bool {
 }
 
 func (s Set[K]) containsHash1Key(hash1 uint64, key K) bool {
-	index := hash1 & uint64(len(s.entries)-1)
+	index := s.home(hash1)
 	entry := s.entries[index]
 	for entry.hash1 != 0 {
 		if entry.hash1 == hash1 && entry.key.Equals(key) {
@@ -55,15 +155,23 @@ func (s *Set[K]) Add(key K) {
 	s.addHash1Key(hash1, key)
 }
 
-func (s *Set[K]) addHash1Key(hash1 uint64, key K) {
+// AddReturningPresent adds the given key to the set.
+// It returns true if the key was already in the set.
+func (s *Set[K]) AddReturningPresent(key K) bool {
+	hash1 := key.Hash() | fullBit
+	return s.addHash1Key(hash1, key)
+}
+
+// addHash1Key adds the key and returns true if it was already present.
+func (s *Set[K]) addHash1Key(hash1 uint64, key K) bool {
 	if s.entries == nil {
 		s.init()
 	}
-	index := hash1 & uint64(len(s.entries)-1)
+	index := s.home(hash1)
 	entry := s.entries[index]
 	for entry.hash1 != 0 {
 		if entry.hash1 == hash1 && entry.key.Equals(key) {
-			return
+			return true
 		}
 		index = (index + 1) & uint64(len(s.entries)-1)
 		entry = s.entries[index]
@@ -74,11 +182,13 @@ func (s *Set[K]) addHash1Key(hash1 uint64, key K) {
 	if s.size > 3*len(s.entries)/4 {
 		s.resize(len(s.entries) * 2)
 	}
+	return false
 }
 
 func (s *Set[K]) resize(cap int) {
 	entries := s.entries
 	s.size = 0
+	s.popIndex = 0
 	s.hash = emptySetHash
 	s.entries = make([]setEntry[K], cap)
 	for _, entry := range entries {
@@ -88,6 +198,56 @@ func (s *Set[K]) resize(cap int) {
 	}
 }
 
+// Rehash gives the set a new random seed, as with WithSetRandomSeed,
+// and reinserts all keys accordingly.
+func (s *Set[K]) Rehash() {
+	s.salt = saltFor(maphash.MakeSeed())
+	if s.entries != nil {
+		s.resize(len(s.entries))
+	}
+}
+
+// Reserve grows the set so that additional elements can be added without resizing.
+// It does nothing if the set already has room for them.
+func (s *Set[K]) Reserve(additional int) {
+	c := capacityFor(s.size+additional, defaultMaxLoadFactor)
+	if c <= len(s.entries) {
+		return
+	}
+	if s.entries == nil {
+		s.entries = make([]setEntry[K], c)
+		s.hash = emptySetHash
+		return
+	}
+	s.resize(c)
+}
+
+// AddAll adds all the given keys to the set.
+// The set is grown at most once.
+func (s *Set[K]) AddAll(keys []K) {
+	if len(keys) == 0 {
+		return
+	}
+	s.Reserve(len(keys))
+	for _, key := range keys {
+		s.addHash1Key(key.Hash()|fullBit, key)
+	}
+}
+
+// AddAllFromSet adds all keys from the given set to the set.
+// It is an in-place Union.
+func (s *Set[K]) AddAllFromSet(t *Set[K]) {
+	if t.size == 0 {
+		return
+	}
+	s.Reserve(t.size)
+	for _, entry := range t.entries {
+		if entry.hash1 != 0 {
+			s.addHash1Key(entry.hash1, entry.key)
+		}
+	}
+}
+
 // Remove removes the given key from the set.
 func (s *Set[K]) Remove(key K) {
 	if s.size == 0 {
@@ -97,38 +257,188 @@ func (s *Set[K]) Remove(key K) {
 	s.removeHash1Key(hash1, key)
 }
 
-func (s *Set[K]) removeHash1Key(hash1 uint64, key K) {
-	index := hash1 & uint64(len(s.entries)-1)
+// RemoveReturningPresent removes the given key from the set.
+// It returns true if the key was in the set.
+func (s *Set[K]) RemoveReturningPresent(key K) bool {
+	if s.size == 0 {
+		return false
+	}
+	hash1 := key.Hash() | fullBit
+	return s.removeHash1Key(hash1, key)
+}
+
+// removeHash1Key removes the key and returns true if it was present.
+func (s *Set[K]) removeHash1Key(hash1 uint64, key K) bool {
+	index := s.home(hash1)
 	entry := s.entries[index]
 	for entry.hash1 != 0 {
 		if entry.hash1 == hash1 && entry.key.Equals(key) {
-			s.entries[index] = setEntry[K]{}
-			s.size--
-			s.hash ^= hash1
-			if len(s.entries) > initialCapacity && s.size < len(s.entries)/4 {
-				s.resize(len(s.entries) / 2)
+			s.removeAt(index)
+			return true
+		}
+		index = (index + 1) & uint64(len(s.entries)-1)
+		entry = s.entries[index]
+	}
+	return false
+}
+
+// Pop removes an arbitrary key from the set and returns it.
+// The second return value is false if the set is empty.
+func (s *Set[K]) Pop() (K, bool) {
+	if s.size > 0 {
+		// Resume at the slot of the last pop, as Map.Pop does.
+		mask := len(s.entries) - 1
+		for i := range s.entries {
+			index := (s.popIndex + i) & mask
+			if entry := s.entries[index]; entry.hash1 != 0 {
+				s.popIndex = index
+				s.removeAt(uint64(index))
+				return entry.key, true
 			}
-			index = (index + 1) & uint64(len(s.entries)-1)
-			for s.entries[index].hash1 != 0 {
-				entry := s.entries[index]
-				s.entries[index] = setEntry[K]{}
-				s.size--
-				s.hash ^= entry.hash1
-				s.addHash1Key(entry.hash1, entry.key)
-				index = (index + 1) & uint64(len(s.entries)-1)
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+// sampleScanLoad is the load factor below which Sample scans the backing array
+// rather than probing random slots. Probing draws a random number and reads a
+// random slot 1/load times on average; scanning reads half the slots in order.
+// BenchmarkSampleProbeCutoff and BenchmarkSampleScanCutoff show that the two cost
+// about the same at this load, and the 0.1, 0.5 and 0.75 benchmarks show probing
+// ahead by more than ten times at the loads a set has after growing or shrinking.
+// So the scan only runs for sets left sparse by Reserve or a large NewSet capacity.
+const sampleScanLoad = 1.0 / 256
+
+// Sample returns a uniformly random element of the set, using r as the source of randomness.
+// The second return value is false if the set is empty.
+// In a dense set Sample probes random slots until it finds an element;
+// in a sparse one, where that could take many tries, it picks one in a single pass.
+func (s *Set[K]) Sample(r *rand.Rand) (K, bool) {
+	if s.size == 0 {
+		var zero K
+		return zero, false
+	}
+	if float64(s.size) < sampleScanLoad*float64(len(s.entries)) {
+		return s.sampleScan(r), true
+	}
+	return s.sampleProbe(r), true
+}
+
+// sampleProbe picks random slots until one is occupied.
+func (s *Set[K]) sampleProbe(r *rand.Rand) K {
+	for {
+		if entry := s.entries[r.IntN(len(s.entries))]; entry.hash1 != 0 {
+			return entry.key
+		}
+	}
+}
+
+// sampleScan picks the n-th element for a random n in a single pass.
+func (s *Set[K]) sampleScan(r *rand.Rand) K {
+	n := r.IntN(s.size)
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			if n == 0 {
+				return entry.key
 			}
-			return
+			n--
+		}
+	}
+	panic("hashmap: set size out of sync")
+}
+
+// RemoveAll removes the given keys from the set.
+// The set is shrunk at most once, after all keys have been removed.
+func (s *Set[K]) RemoveAll(keys []K) {
+	if s.size == 0 {
+		return
+	}
+	for _, key := range keys {
+		hash1 := key.Hash() | fullBit
+		index := s.home(hash1)
+		for entry := s.entries[index]; entry.hash1 != 0; entry = s.entries[index] {
+			if entry.hash1 == hash1 && entry.key.Equals(key) {
+				s.deleteAt(index)
+				break
+			}
+			index = (index + 1) & uint64(len(s.entries)-1)
 		}
+	}
+	s.shrinkToFit()
+}
+
+// RetainAll removes the keys that are not in the given slice from the set.
+// The set is shrunk at most once, after all keys have been removed.
+func (s *Set[K]) RetainAll(keys []K) {
+	if s.size == 0 {
+		return
+	}
+	if len(keys) == 0 {
+		s.Clear()
+		return
+	}
+	keep := NewSet[K](len(keys))
+	keep.AddAll(keys)
+	for i := 0; i < len(s.entries); {
+		entry := s.entries[i]
+		if entry.hash1 != 0 && !keep.containsHash1Key(entry.hash1, entry.key) {
+			// deleteAt may move a following entry into slot i, so look at it again.
+			s.deleteAt(uint64(i))
+			continue
+		}
+		i++
+	}
+	s.shrinkToFit()
+}
+
+// shrinkToFit halves the backing array as many times as removeAt would have,
+// with a single resize.
+func (s *Set[K]) shrinkToFit() {
+	c := len(s.entries)
+	for c > initialCapacity && s.size < c/4 {
+		c /= 2
+	}
+	if c != len(s.entries) {
+		s.resize(c)
+	}
+}
+
+// removeAt removes the entry at the given index and shrinks the set if needed.
+func (s *Set[K]) removeAt(index uint64) {
+	s.deleteAt(index)
+	if len(s.entries) > initialCapacity && s.size < len(s.entries)/4 {
+		s.resize(len(s.entries) / 2)
+	}
+}
+
+// deleteAt removes the entry at the given index without shrinking the set
+// and reinserts the entries that follow it in the probe sequence.
+func (s *Set[K]) deleteAt(index uint64) {
+	s.hash ^= s.entries[index].hash1
+	s.entries[index] = setEntry[K]{}
+	s.size--
+	index = (index + 1) & uint64(len(s.entries)-1)
+	for s.entries[index].hash1 != 0 {
+		entry := s.entries[index]
+		s.entries[index] = setEntry[K]{}
+		s.size--
+		s.hash ^= entry.hash1
+		s.addHash1Key(entry.hash1, entry.key)
 		index = (index + 1) & uint64(len(s.entries)-1)
-		entry = s.entries[index]
 	}
 }
 
 // ForEach calls the given function for each key in the set.
+// If f returns an error, iteration stops and ForEach returns that error,
+// unless it is ErrStopIteration, in which case ForEach returns nil.
 func (s *Set[K]) ForEach(f func(K) error) error {
 	for _, entry := range s.entries {
 		if entry.hash1 != 0 {
 			if err := f(entry.key); err != nil {
+				if err == ErrStopIteration {
+					return nil
+				}
 				return err
 			}
 		}
@@ -136,12 +446,64 @@ func (s *Set[K]) ForEach(f func(K) error) error {
 	return nil
 }
 
+// ForEachSorted is like ForEach, but calls f in the order of the keys given by less.
+// f must not modify the set.
+func (s *Set[K]) ForEachSorted(less func(K, K) bool, f func(K) error) error {
+	p := sortedIndexPool.Get().(*[]int)
+	defer func() {
+		*p = (*p)[:0]
+		sortedIndexPool.Put(p)
+	}()
+	for i, entry := range s.entries {
+		if entry.hash1 != 0 {
+			*p = append(*p, i)
+		}
+	}
+	slices.SortFunc(*p, func(i, j int) int {
+		return compareBy(less, s.entries[i].key, s.entries[j].key)
+	})
+	for _, i := range *p {
+		if err := f(s.entries[i].key); err != nil {
+			if err == ErrStopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns an iterator over all keys in the set in unspecified order.
+// The set must not be modified during iteration.
+func (s *Set[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, entry := range s.entries {
+			if entry.hash1 != 0 {
+				if !yield(entry.key) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ToSlice returns a slice of all keys in the set in unspecified order.
+func (s *Set[K]) ToSlice() []K {
+	keys := make([]K, 0, s.size)
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
 // Copy returns a copy of the set.
 func (s *Set[K]) Copy() *Set[K] {
+	c := &Set[K]{salt: s.salt}
 	if s.size == 0 {
-		return &Set[K]{}
+		return c
 	}
-	c := &Set[K]{}
 	c.entries = make([]setEntry[K], len(s.entries))
 	copy(c.entries, s.entries)
 	c.size = s.size
@@ -199,6 +561,11 @@ func (s *Set[K]) IsSubset(t *Set[K]) bool {
 	return true
 }
 
+// IsSuperset returns true if the given set is a subset of the set.
+func (s *Set[K]) IsSuperset(t *Set[K]) bool {
+	return t.IsSubset(s)
+}
+
 // IsDisjoint returns true if the intersection of the set and the given set is empty.
 func (s *Set[K]) IsDisjoint(t *Set[K]) bool {
 	if s.size == 0 || t.size == 0 {
@@ -269,3 +636,232 @@ func (s *Set[K]) Difference(t *Set[K]) *Set[K] {
 	}
 	return r
 }
+
+// SymmetricDifference returns a new set with the elements that are in exactly one of the sets.
+func (s *Set[K]) SymmetricDifference(t *Set[K]) *Set[K] {
+	if s.size < t.size {
+		s, t = t, s
+	}
+	r := s.Copy()
+	for _, entry := range t.entries {
+		if entry.hash1 != 0 {
+			if s.containsHash1Key(entry.hash1, entry.key) {
+				r.removeHash1Key(entry.hash1, entry.key)
+			} else {
+				r.addHash1Key(entry.hash1, entry.key)
+			}
+		}
+	}
+	return r
+}
+
+// Filter returns a new set with the keys for which f returns true.
+func (s *Set[K]) Filter(f func(K) bool) *Set[K] {
+	return FilterSet(s, f)
+}
+
+// Every returns true if f returns true for every key in the set.
+// It returns true for an empty set.
+func (s *Set[K]) Every(f func(K) bool) bool {
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 && !f(entry.key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any returns true if f returns true for at least one key in the set.
+// It returns false for an empty set.
+func (s *Set[K]) Any(f func(K) bool) bool {
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 && f(entry.key) {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectSet returns a new set with the keys from seq.
+func CollectSet[K Comparable[K]](seq iter.Seq[K]) *Set[K] {
+	r := &Set[K]{}
+	for k := range seq {
+		r.Add(k)
+	}
+	return r
+}
+
+// SetFromSlice returns a new set with the given keys.
+func SetFromSlice[K Comparable[K]](keys []K) *Set[K] {
+	r := &Set[K]{}
+	r.AddAll(keys)
+	return r
+}
+
+// maxPowerSetSize is the largest set PowerSet accepts; its power set has 2^20 subsets.
+const maxPowerSetSize = 20
+
+// PowerSet returns a new set with all subsets of s,
+// including the empty set and a copy of s itself.
+// It panics if s has more than 20 elements.
+func PowerSet[K Comparable[K]](s *Set[K]) *Set[*Set[K]] {
+	if s.size > maxPowerSetSize {
+		panic("hashmap: power set too large")
+	}
+	keys := s.ToSlice()
+	r := NewSet[*Set[K]](1 << len(keys))
+	for bits := 0; bits < 1<<len(keys); bits++ {
+		subset := &Set[K]{}
+		for i, k := range keys {
+			if bits&(1<<i) != 0 {
+				subset.Add(k)
+			}
+		}
+		r.Add(subset)
+	}
+	return r
+}
+
+// maxCombinations is the largest number of subsets Combinations returns.
+const maxCombinations = 100000
+
+// binomial returns n choose k, or limit+1 if it is larger than limit.
+func binomial(n, k, limit int) int {
+	k = min(k, n-k)
+	c := 1
+	for i := 0; i < k; i++ {
+		c = c * (n - i) / (i + 1)
+		if c > limit {
+			return limit + 1
+		}
+	}
+	return c
+}
+
+// Combinations returns a new set with all subsets of s that have exactly k elements.
+// It is empty if k is larger than the size of s.
+// It panics if k is negative or if there are more than 100000 such subsets.
+func Combinations[K Comparable[K]](s *Set[K], k int) *Set[*Set[K]] {
+	if k < 0 {
+		panic("hashmap: negative combination size")
+	}
+	if k > s.size {
+		return &Set[*Set[K]]{}
+	}
+	n := binomial(s.size, k, maxCombinations)
+	if n > maxCombinations {
+		panic("hashmap: too many combinations")
+	}
+	r := NewSet[*Set[K]](n)
+	for subset := range s.CombinationsIter(k) {
+		r.Add(subset)
+	}
+	return r
+}
+
+// CombinationsIter returns an iterator over all subsets of the set that have exactly k elements.
+// Each subset is a new set, created when the iterator reaches it.
+// The set must not be modified during iteration. CombinationsIter panics if k is negative.
+func (s *Set[K]) CombinationsIter(k int) iter.Seq[*Set[K]] {
+	if k < 0 {
+		panic("hashmap: negative combination size")
+	}
+	return func(yield func(*Set[K]) bool) {
+		keys := s.ToSlice()
+		if k > len(keys) {
+			return
+		}
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			subset := NewSet[K](k)
+			for _, i := range indices {
+				subset.Add(keys[i])
+			}
+			if !yield(subset) {
+				return
+			}
+			// Advance the rightmost index that can still move right,
+			// and reset the ones after it to follow it.
+			i := k - 1
+			for i >= 0 && indices[i] == len(keys)-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+// CartesianProduct returns a new set with a Pair for every key of s combined with every key of t.
+func CartesianProduct[A Comparable[A], B Comparable[B]](s *Set[A], t *Set[B]) *Set[Pair[A, B]] {
+	r := NewSet[Pair[A, B]](s.size * t.size)
+	for _, a := range s.entries {
+		if a.hash1 == 0 {
+			continue
+		}
+		for _, b := range t.entries {
+			if b.hash1 != 0 {
+				r.Add(Pair[A, B]{a.key, b.key})
+			}
+		}
+	}
+	return r
+}
+
+// FilterSet returns a new set with the keys of s for which f returns true.
+func FilterSet[K Comparable[K]](s *Set[K], f func(K) bool) *Set[K] {
+	r := NewSet[K](s.size)
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 && f(entry.key) {
+			r.addHash1Key(entry.hash1, entry.key)
+		}
+	}
+	return r
+}
+
+// ReduceSet applies f cumulatively to the keys of s in unspecified order,
+// starting with initial, and returns the result.
+func ReduceSet[K Comparable[K], R any](s *Set[K], initial R, f func(R, K) R) R {
+	r := initial
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			r = f(r, entry.key)
+		}
+	}
+	return r
+}
+
+// Number is the constraint satisfied by the numeric wrapper types such as Int and Float64.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// SumSet returns the sum of the keys of s.
+func SumSet[N interface {
+	Comparable[N]
+	Number
+}](s *Set[N]) N {
+	return ReduceSet(s, 0, func(r N, k N) N { return r + k })
+}
+
+// JoinSet returns the keys of s in sorted order, separated by sep.
+func JoinSet(s *Set[String], sep string) string {
+	keys := make([]string, 0, s.size)
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			keys = append(keys, string(entry.key))
+		}
+	}
+	slices.Sort(keys)
+	return strings.Join(keys, sep)
+}
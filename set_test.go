@@ -1,6 +1,14 @@
 package hashmap
 
-import "testing"
+import (
+	"fmt"
+	"hash/maphash"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
 
 func TestSet(t *testing.T) {
 	s := Set[Int]{}
@@ -114,6 +122,30 @@ func TestSetIsSubset(t *testing.T) {
 	}
 }
 
+func TestSetIsSuperset(t *testing.T) {
+	tests := []struct {
+		a, b *Set[Int]
+		r    bool
+	}{
+		{intSet(1, 2, 3), intSet(1, 2, 3), true},
+		{intSet(1, 2), intSet(1, 2, 3), false},
+		{intSet(1, 2, 3, 4), intSet(1, 2, 3), true},
+		{intSet(2, 3, 4, 5), intSet(1, 2, 3), false},
+		{intSet(1, 2, 3), intSet(), true},
+		{intSet(), intSet(1, 2, 3), false},
+		{intSet(), intSet(), true},
+	}
+	for _, test := range tests {
+		if test.a.IsSuperset(test.b) != test.r {
+			if test.r {
+				t.Errorf("expected %v to be superset of %v", test.a, test.b)
+			} else {
+				t.Errorf("expected %v to not be superset of %v", test.a, test.b)
+			}
+		}
+	}
+}
+
 func TestSetIsDisjoint(t *testing.T) {
 	tests := []struct {
 		a, b *Set[Int]
@@ -195,3 +227,717 @@ func TestSetDifference(t *testing.T) {
 		}
 	}
 }
+
+func TestSetSymmetricDifference(t *testing.T) {
+	tests := []struct {
+		a, b, r *Set[Int]
+	}{
+		{intSet(1, 2, 3), intSet(1, 2, 3), intSet()},
+		{intSet(1, 2, 3), intSet(1, 2), intSet(3)},
+		{intSet(1, 2, 3), intSet(1, 2, 3, 4), intSet(4)},
+		{intSet(1, 2, 3), intSet(2, 3, 4, 5), intSet(1, 4, 5)},
+		{intSet(1, 2, 3), intSet(4, 5, 6), intSet(1, 2, 3, 4, 5, 6)},
+		{intSet(), intSet(1, 2, 3), intSet(1, 2, 3)},
+		{intSet(1, 2, 3), intSet(), intSet(1, 2, 3)},
+		{intSet(), intSet(), intSet()},
+	}
+	for _, test := range tests {
+		if !test.a.SymmetricDifference(test.b).Equals(test.r) {
+			t.Errorf("expected %v symmetric difference %v to equal %v", test.a, test.b, test.r)
+		}
+	}
+}
+
+func TestSetSample(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	if _, ok := intSet().Sample(r); ok {
+		t.Errorf("expected Sample on empty set to fail")
+	}
+	tests := []struct {
+		reserve int
+		scan    bool
+	}{
+		{0, false},
+		{10000, true},
+	}
+	for _, test := range tests {
+		s := Set[Int]{}
+		s.Reserve(test.reserve)
+		for i := 0; i < 10; i++ {
+			s.Add(Int(i))
+		}
+		if scan := s.LoadFactor() < sampleScanLoad; scan != test.scan {
+			t.Fatalf("reserve %d: expected scan %v, got load factor %v", test.reserve, test.scan, s.LoadFactor())
+		}
+		counts := make([]int, 10)
+		for i := 0; i < 20000; i++ {
+			k, ok := s.Sample(r)
+			if !ok || !s.Contains(k) {
+				t.Fatalf("reserve %d: expected an element of the set, got %d", test.reserve, k)
+			}
+			counts[k]++
+		}
+		for k, n := range counts {
+			if n < 1700 || n > 2300 {
+				t.Errorf("reserve %d: expected about 2000 samples of %d, got %d", test.reserve, k, n)
+			}
+		}
+	}
+}
+
+func TestSetPop(t *testing.T) {
+	s := intSet()
+	if _, ok := s.Pop(); ok {
+		t.Errorf("expected Pop on empty set to fail")
+	}
+	for i := 0; i < 100; i++ {
+		s.Add(Int(i))
+	}
+	popped := intSet()
+	for {
+		k, ok := s.Pop()
+		if !ok {
+			break
+		}
+		if popped.Contains(k) {
+			t.Errorf("expected key %d to be popped once", k)
+		}
+		popped.Add(k)
+		for j := 0; j < 100; j++ {
+			if s.Contains(Int(j)) == popped.Contains(Int(j)) {
+				t.Fatalf("expected key %d to be in exactly one of the sets", j)
+			}
+		}
+	}
+	if popped.Size() != 100 || s.Size() != 0 {
+		t.Errorf("expected all 100 keys to be popped, got %d", popped.Size())
+	}
+	if !s.Equals(intSet()) || s.Hash() != intSet().Hash() {
+		t.Errorf("expected drained set to equal empty set")
+	}
+	// Pops interleaved with adds must still find every key.
+	for i := 0; i < 1000; i++ {
+		s.Add(Int(i))
+	}
+	popped = intSet()
+	for i := 0; ; i++ {
+		if i%3 == 0 && i < 1500 {
+			s.Add(Int(1000 + i))
+		}
+		k, ok := s.Pop()
+		if !ok {
+			break
+		}
+		if popped.Contains(k) {
+			t.Fatalf("expected key %d to be popped once", k)
+		}
+		popped.Add(k)
+	}
+	if popped.Size() != 1500 || s.Size() != 0 {
+		t.Errorf("expected 1500 keys popped and an empty set, got %d and size %d", popped.Size(), s.Size())
+	}
+}
+
+func TestSetToSlice(t *testing.T) {
+	keys := intSet(3, 1, 2).ToSlice()
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	if !reflect.DeepEqual(keys, []Int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", keys)
+	}
+	if len(intSet().ToSlice()) != 0 {
+		t.Errorf("expected empty slice for empty set")
+	}
+}
+
+func TestSetClear(t *testing.T) {
+	s := Set[Int]{}
+	if !s.IsEmpty() {
+		t.Errorf("expected zero set to be empty")
+	}
+	s.Clear()
+	for _, n := range []int{5, 100} {
+		for i := 0; i < n; i++ {
+			s.Add(Int(i))
+		}
+		if s.IsEmpty() {
+			t.Errorf("expected set to not be empty")
+		}
+		s.Clear()
+		if !s.IsEmpty() || s.Contains(Int(1)) {
+			t.Errorf("expected set to be empty after Clear")
+		}
+		if !s.Equals(intSet()) || s.Hash() != intSet().Hash() {
+			t.Errorf("expected cleared set to equal empty set")
+		}
+	}
+}
+
+func TestNewSet(t *testing.T) {
+	for _, n := range []int{-1, 0, 1, 12, 13, 100, 1000} {
+		s := NewSet[Int](n)
+		c := len(s.entries)
+		for i := 0; i < n; i++ {
+			s.Add(Int(i))
+		}
+		if n > 0 && len(s.entries) != c {
+			t.Errorf("expected no resize for capacity %d, got %d -> %d", n, c, len(s.entries))
+		}
+	}
+	if s := NewSet[Int](10); !s.Equals(intSet()) || s.Hash() != intSet().Hash() {
+		t.Errorf("expected new set to equal empty set")
+	}
+}
+
+func TestSetReserve(t *testing.T) {
+	s := intSet(1, 2, 3)
+	s.Reserve(1000)
+	c := len(s.entries)
+	for i := 0; i < 1000; i++ {
+		s.Add(Int(i + 4))
+	}
+	if len(s.entries) != c {
+		t.Errorf("expected no resize after Reserve, got %d -> %d", c, len(s.entries))
+	}
+	if s.Size() != 1003 {
+		t.Errorf("expected size 1003, got %d", s.Size())
+	}
+}
+
+func TestSetDiagnostics(t *testing.T) {
+	s := Set[Int]{}
+	if s.Capacity() != 0 || s.LoadFactor() != 0 {
+		t.Errorf("expected zero diagnostics for zero set")
+	}
+	s = *intSet(1, 2, 3, 4)
+	if s.Capacity() != 16 || s.LoadFactor() != 0.25 {
+		t.Errorf("expected capacity 16 and load factor 0.25, got %d and %f", s.Capacity(), s.LoadFactor())
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	keys := slices.Sorted(intSet(3, 1, 2).All())
+	if !reflect.DeepEqual(keys, []Int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", keys)
+	}
+	n := 0
+	for range intSet(1, 2, 3).All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("expected iteration to stop after break, got %d", n)
+	}
+}
+
+func TestSetForEachStop(t *testing.T) {
+	n := 0
+	err := intSet(1, 2, 3).ForEach(func(k Int) error {
+		n++
+		return ErrStopIteration
+	})
+	if err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 call, got %d", n)
+	}
+}
+
+func TestFilterSet(t *testing.T) {
+	tests := []struct {
+		a, r *Set[Int]
+	}{
+		{intSet(1, 2, 3, 4), intSet(2, 4)},
+		{intSet(1, 3), intSet()},
+		{intSet(), intSet()},
+	}
+	for _, test := range tests {
+		if r := FilterSet(test.a, func(k Int) bool { return k%2 == 0 }); !r.Equals(test.r) {
+			t.Errorf("expected filter of %v to equal %v", test.a, test.r)
+		}
+	}
+}
+
+func TestSetFilter(t *testing.T) {
+	s := intSet(1, 2, 3, 4)
+	if r := s.Filter(func(k Int) bool { return k > 2 }); !r.Equals(intSet(3, 4)) {
+		t.Errorf("expected {3 4}, got %v", r.ToSlice())
+	}
+	if s.Size() != 4 {
+		t.Errorf("expected original set to be unchanged, got size %d", s.Size())
+	}
+	if r := intSet().Filter(func(k Int) bool { return true }); r.Size() != 0 {
+		t.Errorf("expected empty set, got %v", r.ToSlice())
+	}
+}
+
+func TestSetEveryAny(t *testing.T) {
+	even := func(k Int) bool { return k%2 == 0 }
+	tests := []struct {
+		s          *Set[Int]
+		every, any bool
+	}{
+		{intSet(2, 4, 6), true, true},
+		{intSet(1, 2, 3), false, true},
+		{intSet(1, 3), false, false},
+		{intSet(), true, false},
+	}
+	for _, test := range tests {
+		if r := test.s.Every(even); r != test.every {
+			t.Errorf("expected Every of %v to be %v, got %v", test.s.ToSlice(), test.every, r)
+		}
+		if r := test.s.Any(even); r != test.any {
+			t.Errorf("expected Any of %v to be %v, got %v", test.s.ToSlice(), test.any, r)
+		}
+	}
+	n := 0
+	intSet(1, 2, 3).Every(func(k Int) bool { n++; return false })
+	if n != 1 {
+		t.Errorf("expected Every to stop after first failure, got %d calls", n)
+	}
+}
+
+func ExampleReduceSet() {
+	s := Set[String]{}
+	s.Add("c")
+	s.Add("a")
+	s.Add("b")
+	concat := ReduceSet(&s, "", func(r string, k String) string { return r + string(k) })
+	// The keys are visited in unspecified order, so sort the result to print it.
+	letters := []byte(concat)
+	slices.Sort(letters)
+	fmt.Println(string(letters))
+	// Output: abc
+}
+
+func TestSetAddRemoveReturningPresent(t *testing.T) {
+	s := Set[Int]{}
+	if s.RemoveReturningPresent(1) {
+		t.Errorf("expected 1 to be absent from empty set")
+	}
+	if s.AddReturningPresent(1) {
+		t.Errorf("expected 1 to be absent before first add")
+	}
+	if !s.AddReturningPresent(1) {
+		t.Errorf("expected 1 to be present on second add")
+	}
+	if !s.RemoveReturningPresent(1) {
+		t.Errorf("expected 1 to be present on first remove")
+	}
+	if s.RemoveReturningPresent(1) {
+		t.Errorf("expected 1 to be absent on second remove")
+	}
+	if s.Size() != 0 {
+		t.Errorf("expected empty set, got %v", &s)
+	}
+}
+
+func TestSetAddAll(t *testing.T) {
+	s := intSet(1)
+	s.AddAll([]Int{2, 3, 1, 3})
+	if !s.Equals(intSet(1, 2, 3)) {
+		t.Errorf("expected {1 2 3}, got %v", s)
+	}
+	keys := make([]Int, 100)
+	for i := range keys {
+		keys[i] = Int(i)
+	}
+	big := Set[Int]{}
+	big.AddAll(keys)
+	if big.Size() != 100 || big.Capacity() != capacityFor(100, defaultMaxLoadFactor) {
+		t.Errorf("expected 100 keys in a single allocation, got size %d, capacity %d", big.Size(), big.Capacity())
+	}
+}
+
+func TestSetAddAllFromSet(t *testing.T) {
+	tests := []struct {
+		a, b, r *Set[Int]
+	}{
+		{intSet(1, 2), intSet(2, 3), intSet(1, 2, 3)},
+		{intSet(), intSet(1), intSet(1)},
+		{intSet(1), intSet(), intSet(1)},
+	}
+	for _, test := range tests {
+		test.a.AddAllFromSet(test.b)
+		if !test.a.Equals(test.r) {
+			t.Errorf("expected %v, got %v", test.r, test.a)
+		}
+	}
+}
+
+func TestSetRemoveAll(t *testing.T) {
+	s := Set[Int]{}
+	keys := make([]Int, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		s.Add(Int(i))
+		if i%10 != 0 {
+			keys = append(keys, Int(i))
+		}
+	}
+	s.RemoveAll(keys)
+	s.RemoveAll([]Int{2000})
+	if s.Size() != 100 {
+		t.Errorf("expected size 100, got %d", s.Size())
+	}
+	if c := capacityFor(100, defaultMaxLoadFactor); s.Capacity() > 2*c {
+		t.Errorf("expected set to shrink to at most %d, got capacity %d", 2*c, s.Capacity())
+	}
+	r := Set[Int]{}
+	for i := 0; i < 1000; i += 10 {
+		r.Add(Int(i))
+	}
+	if !s.Equals(&r) || s.Hash() != r.Hash() {
+		t.Errorf("expected multiples of 10, got %v", s)
+	}
+}
+
+func TestSetRetainAll(t *testing.T) {
+	s := Set[Int]{}
+	for i := 0; i < 1000; i++ {
+		s.Add(Int(i))
+	}
+	keys := make([]Int, 0, 100)
+	for i := 0; i < 1100; i += 11 {
+		keys = append(keys, Int(i))
+	}
+	s.RetainAll(keys)
+	r := Set[Int]{}
+	for i := 0; i < 1000; i += 11 {
+		r.Add(Int(i))
+	}
+	if s.Size() != r.Size() || !s.Equals(&r) || s.Hash() != r.Hash() {
+		t.Errorf("expected multiples of 11 below 1000, got %v", s)
+	}
+	for i := 0; i < 1000; i++ {
+		if s.Contains(Int(i)) != (i%11 == 0) {
+			t.Errorf("expected Contains(%d) to return %v", i, i%11 == 0)
+		}
+	}
+	s.RetainAll(nil)
+	if s.Size() != 0 {
+		t.Errorf("expected empty set, got %v", s)
+	}
+}
+
+func TestCollectSet(t *testing.T) {
+	s := intSet(1, 2, 3)
+	if r := CollectSet(s.All()); !r.Equals(s) {
+		t.Errorf("expected %v, got %v", s, r)
+	}
+	if r := CollectSet(slices.Values([]Int{1, 1, 2})); !r.Equals(intSet(1, 2)) {
+		t.Errorf("expected {1 2}, got %v", r)
+	}
+}
+
+func TestSetFromSlice(t *testing.T) {
+	tests := []struct {
+		keys []Int
+		r    *Set[Int]
+	}{
+		{[]Int{3, 1, 2, 1}, intSet(1, 2, 3)},
+		{nil, intSet()},
+	}
+	for _, test := range tests {
+		if r := SetFromSlice(test.keys); !r.Equals(test.r) {
+			t.Errorf("expected %v, got %v", test.r, r)
+		}
+	}
+}
+
+func TestSetForEachSorted(t *testing.T) {
+	greater := func(a, b Int) bool { return a > b }
+	var first []Int
+	for run := 0; run < 3; run++ {
+		s := Set[Int]{}
+		for i := 0; i < 100; i++ {
+			s.Add(Int((i*37 + run*11) % 100))
+		}
+		var keys []Int
+		if err := s.ForEachSorted(greater, func(k Int) error {
+			keys = append(keys, k)
+			return nil
+		}); err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if len(keys) != 100 || keys[0] != 99 || keys[99] != 0 {
+			t.Errorf("expected 100 keys in descending order, got %v", keys)
+		}
+		if run == 0 {
+			first = keys
+		} else if !slices.Equal(keys, first) {
+			t.Errorf("expected run %d to match the first run, got %v", run, keys)
+		}
+	}
+}
+
+func TestSetDrain(t *testing.T) {
+	s := intSet(3, 1, 2)
+	keys := s.Drain()
+	slices.Sort(keys)
+	if !slices.Equal(keys, []Int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", keys)
+	}
+	if s.Size() != 0 || !s.Equals(intSet()) || s.Hash() != intSet().Hash() {
+		t.Errorf("expected drained set to equal the empty set, got %v", s)
+	}
+	s.Add(4)
+	if !s.Equals(intSet(4)) || s.Hash() != intSet(4).Hash() {
+		t.Errorf("expected drained set to be reusable, got %v", s)
+	}
+}
+
+func TestSetSeed(t *testing.T) {
+	a := NewSet[Int](0, WithSetRandomSeed[Int]())
+	b := NewSet[Int](0, WithSetSeed[Int](maphash.MakeSeed()))
+	plain := Set[Int]{}
+	for i := 0; i < 100; i++ {
+		a.Add(Int(i))
+		b.Add(Int(i))
+		plain.Add(Int(i))
+	}
+	for i := 0; i < 100; i++ {
+		if !a.Contains(Int(i)) || !b.Contains(Int(i)) {
+			t.Errorf("expected seeded sets to contain %d", i)
+		}
+	}
+	if !a.Equals(b) || !a.Equals(&plain) || a.Hash() != plain.Hash() {
+		t.Errorf("expected sets with different seeds to be equal")
+	}
+	if u := a.Union(intSet(100)); u.Size() != 101 || !u.Contains(100) {
+		t.Errorf("expected union across seeds to work, got %v", u)
+	}
+	if d := a.Difference(b); d.Size() != 0 {
+		t.Errorf("expected empty difference, got %v", d)
+	}
+	for i := 0; i < 90; i++ {
+		a.Remove(Int(i))
+	}
+	if a.Size() != 10 || !a.Equals(FilterSet(&plain, func(k Int) bool { return k >= 90 })) {
+		t.Errorf("expected {90..99}, got %v", a)
+	}
+}
+
+func TestSumSet(t *testing.T) {
+	if n := SumSet(intSet(1, 2, 3, 4)); n != 10 {
+		t.Errorf("expected 10, got %d", n)
+	}
+	if n := SumSet(intSet()); n != 0 {
+		t.Errorf("expected 0, got %d", n)
+	}
+	f := Set[Float64]{}
+	f.Add(0.5)
+	f.Add(1.25)
+	if n := SumSet(&f); n != 1.75 {
+		t.Errorf("expected 1.75, got %v", n)
+	}
+}
+
+func TestJoinSet(t *testing.T) {
+	tests := []struct {
+		keys []String
+		sep  string
+		r    string
+	}{
+		{[]String{"c", "a", "b"}, ", ", "a, b, c"},
+		{[]String{"x"}, ",", "x"},
+		{nil, ",", ""},
+	}
+	for _, test := range tests {
+		if r := JoinSet(SetFromSlice(test.keys), test.sep); r != test.r {
+			t.Errorf("expected %q, got %q", test.r, r)
+		}
+	}
+}
+
+func TestSetPowerSet(t *testing.T) {
+	p := PowerSet(intSet(1, 2, 3))
+	if p.Size() != 8 {
+		t.Errorf("expected 8 subsets, got %d", p.Size())
+	}
+	for _, subset := range []*Set[Int]{intSet(), intSet(1), intSet(2, 3), intSet(1, 2, 3)} {
+		if !p.Contains(subset) {
+			t.Errorf("expected power set to contain %v", subset)
+		}
+	}
+	if p := PowerSet(intSet()); p.Size() != 1 || !p.Contains(intSet()) {
+		t.Errorf("expected power set of the empty set to be {{}}, got %v", p)
+	}
+	big := Set[Int]{}
+	for i := 0; i < 21; i++ {
+		big.Add(Int(i))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for a set with 21 elements")
+		}
+	}()
+	PowerSet(&big)
+}
+
+func TestSetCombinations(t *testing.T) {
+	s := intSet(1, 2, 3, 4)
+	tests := []struct {
+		k        int
+		size     int
+		contains *Set[Int]
+	}{
+		{0, 1, intSet()},
+		{1, 4, intSet(3)},
+		{2, 6, intSet(2, 4)},
+		{4, 1, intSet(1, 2, 3, 4)},
+		{5, 0, nil},
+	}
+	for _, test := range tests {
+		c := Combinations(s, test.k)
+		if c.Size() != test.size {
+			t.Errorf("k=%d: expected %d subsets, got %d", test.k, test.size, c.Size())
+		}
+		if test.contains != nil && !c.Contains(test.contains) {
+			t.Errorf("k=%d: expected combinations to contain %v", test.k, test.contains)
+		}
+		n := 0
+		for subset := range s.CombinationsIter(test.k) {
+			if subset.Size() != test.k || !c.Contains(subset) {
+				t.Errorf("k=%d: unexpected subset %v", test.k, subset)
+			}
+			n++
+		}
+		if n != test.size {
+			t.Errorf("k=%d: expected iterator to yield %d subsets, got %d", test.k, test.size, n)
+		}
+	}
+	n := 0
+	for range s.CombinationsIter(2) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("expected iteration to stop after 3 subsets, got %d", n)
+	}
+	big := Set[Int]{}
+	for i := 0; i < 40; i++ {
+		big.Add(Int(i))
+	}
+	if c := Combinations(&big, 39); c.Size() != 40 {
+		t.Errorf("expected 40 subsets of size 39, got %d", c.Size())
+	}
+	for _, k := range []int{-1, 20} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("k=%d: expected panic", k)
+				}
+			}()
+			Combinations(&big, k)
+		}()
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	p := CartesianProduct(intSet(1, 2, 3), intSet(10, 20))
+	if p.Size() != 6 {
+		t.Errorf("expected 6 pairs, got %d", p.Size())
+	}
+	if !p.Contains(NewPair[Int, Int](1, 10)) || !p.Contains(NewPair[Int, Int](3, 20)) {
+		t.Errorf("expected product to contain (1, 10) and (3, 20)")
+	}
+	if p.Contains(NewPair[Int, Int](10, 1)) {
+		t.Errorf("expected product not to contain the swapped pair (10, 1)")
+	}
+	if p := CartesianProduct(intSet(1, 2), intSet()); p.Size() != 0 {
+		t.Errorf("expected empty product, got %v", p)
+	}
+	s := Set[String]{}
+	s.Add("a")
+	if p := CartesianProduct(&s, intSet(1, 2)); !p.Contains(NewPair[String, Int]("a", 2)) {
+		t.Errorf("expected product of different key types to contain (a, 2)")
+	}
+}
+
+func TestSetRehash(t *testing.T) {
+	s := intSet()
+	for i := 0; i < 100; i++ {
+		s.Add(Int(i))
+	}
+	h := s.Hash()
+	s.Rehash()
+	if s.salt == 0 || s.Hash() != h || s.Size() != 100 {
+		t.Errorf("expected Rehash to keep the keys and hash, got %v", s)
+	}
+	for i := 0; i < 100; i++ {
+		if !s.Contains(Int(i)) {
+			t.Errorf("expected rehashed set to contain %d", i)
+		}
+	}
+}
+
+// The benchmarks below compare the two strategies of Set.Sample at different load factors.
+
+func benchmarkSample(b *testing.B, load float64, sample func(*Set[Int], *rand.Rand) Int) {
+	s := NewSet[Int](3072)
+	for i := 0; i < int(load*4096); i++ {
+		s.Add(Int(i))
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sample(s, r)
+	}
+}
+
+func BenchmarkSampleProbe10(b *testing.B) {
+	benchmarkSample(b, 0.1, (*Set[Int]).sampleProbe)
+}
+
+func BenchmarkSampleScan10(b *testing.B) {
+	benchmarkSample(b, 0.1, (*Set[Int]).sampleScan)
+}
+
+func BenchmarkSampleProbe50(b *testing.B) {
+	benchmarkSample(b, 0.5, (*Set[Int]).sampleProbe)
+}
+
+func BenchmarkSampleScan50(b *testing.B) {
+	benchmarkSample(b, 0.5, (*Set[Int]).sampleScan)
+}
+
+func BenchmarkSampleProbe75(b *testing.B) {
+	benchmarkSample(b, 0.75, (*Set[Int]).sampleProbe)
+}
+
+func BenchmarkSampleScan75(b *testing.B) {
+	benchmarkSample(b, 0.75, (*Set[Int]).sampleScan)
+}
+
+// The cutoff benchmarks run at sampleScanLoad, where Sample switches strategy.
+
+func BenchmarkSampleProbeCutoff(b *testing.B) {
+	benchmarkSample(b, sampleScanLoad, (*Set[Int]).sampleProbe)
+}
+
+func BenchmarkSampleScanCutoff(b *testing.B) {
+	benchmarkSample(b, sampleScanLoad, (*Set[Int]).sampleScan)
+}
+
+func benchmarkSetDrain(b *testing.B, n int) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := NewSet[Int](n)
+		for j := 0; j < n; j++ {
+			s.Add(Int(j))
+		}
+		b.StartTimer()
+		for _, ok := s.Pop(); ok; _, ok = s.Pop() {
+		}
+	}
+}
+
+func BenchmarkSetDrain10k(b *testing.B) {
+	benchmarkSetDrain(b, 10000)
+}
+
+func BenchmarkSetDrain80k(b *testing.B) {
+	benchmarkSetDrain(b, 80000)
+}
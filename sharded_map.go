@@ -0,0 +1,89 @@
+package hashmap
+
+const defaultShards = 32
+
+// ShardedMap is a map partitioned into independently locked shards.
+// It is safe for concurrent use by multiple goroutines and scales better
+// than SyncMap when many goroutines access the map at once.
+// A ShardedMap must be created with NewShardedMap.
+type ShardedMap[K Comparable[K], V any] struct {
+	shards []SyncMap[K, V]
+}
+
+// ShardedMapOption configures a ShardedMap created by NewShardedMap.
+type ShardedMapOption[K Comparable[K], V any] func(*ShardedMap[K, V])
+
+// WithShards sets the number of shards of the map.
+// It panics if n is not positive.
+func WithShards[K Comparable[K], V any](n int) ShardedMapOption[K, V] {
+	if n <= 0 {
+		panic("hashmap: invalid number of shards")
+	}
+	return func(m *ShardedMap[K, V]) {
+		m.shards = make([]SyncMap[K, V], n)
+	}
+}
+
+// NewShardedMap returns a new empty sharded map with 32 shards unless configured otherwise.
+func NewShardedMap[K Comparable[K], V any](opts ...ShardedMapOption[K, V]) *ShardedMap[K, V] {
+	m := &ShardedMap[K, V]{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.shards == nil {
+		m.shards = make([]SyncMap[K, V], defaultShards)
+	}
+	return m
+}
+
+// shard returns the shard for the given key.
+// It uses the high bits of the hash, because the low bits select the slot within the shard.
+func (m *ShardedMap[K, V]) shard(key K) *SyncMap[K, V] {
+	return &m.shards[(key.Hash()>>32)%uint64(len(m.shards))]
+}
+
+// Size returns the number of elements in the map.
+// Concurrent modifications may or may not be reflected in the result.
+func (m *ShardedMap[K, V]) Size() int {
+	size := 0
+	for i := range m.shards {
+		size += m.shards[i].Size()
+	}
+	return size
+}
+
+// Get returns the value associated with the given key.
+// The second return value indicates if the key was found.
+func (m *ShardedMap[K, V]) Get(key K) (V, bool) {
+	return m.shard(key).Get(key)
+}
+
+// Put adds the given key/value pair to the map.
+// If the key already exists, the value is updated.
+func (m *ShardedMap[K, V]) Put(key K, value V) {
+	m.shard(key).Put(key, value)
+}
+
+// Remove removes the given key from the map.
+func (m *ShardedMap[K, V]) Remove(key K) {
+	m.shard(key).Remove(key)
+}
+
+// ForEach calls the given function for each key/value pair in the map.
+// Each shard is read locked in turn while its entries are visited,
+// so f must not call mutating methods on the same map or it will deadlock.
+// Returning ErrStopIteration from f stops the iteration over all shards.
+func (m *ShardedMap[K, V]) ForEach(f func(K, V) error) error {
+	for i := range m.shards {
+		stopped := false
+		err := m.shards[i].ForEach(func(k K, v V) error {
+			err := f(k, v)
+			stopped = err == ErrStopIteration
+			return err
+		})
+		if err != nil || stopped {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package hashmap
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestShardedMap(t *testing.T) {
+	m := NewShardedMap[Int, Int]()
+	if len(m.shards) != 32 {
+		t.Errorf("expected 32 shards, got %d", len(m.shards))
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				k := Int(g*100 + i)
+				m.Put(k, k*2)
+				if v, ok := m.Get(k); !ok || v != k*2 {
+					t.Errorf("expected value %d, got %d", k*2, v)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Size() != 800 {
+		t.Errorf("expected size 800, got %d", m.Size())
+	}
+	for i := 0; i < 800; i += 2 {
+		m.Remove(Int(i))
+	}
+	n := 0
+	m.ForEach(func(k Int, v Int) error {
+		if k%2 == 0 {
+			t.Errorf("expected removed key %d to be gone", k)
+		}
+		n++
+		return nil
+	})
+	if n != 400 {
+		t.Errorf("expected 400 iterations, got %d", n)
+	}
+	if m := NewShardedMap(WithShards[Int, Int](3)); len(m.shards) != 3 {
+		t.Errorf("expected 3 shards, got %d", len(m.shards))
+	}
+}
+
+func TestShardedMapForEachStop(t *testing.T) {
+	m := NewShardedMap[Int, Int]()
+	for i := 0; i < 100; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	n := 0
+	err := m.ForEach(func(k Int, v Int) error {
+		n++
+		return ErrStopIteration
+	})
+	if err != nil || n != 1 {
+		t.Errorf("expected a single call and nil error, got %d calls and %v", n, err)
+	}
+}
+
+// The benchmarks below compare SyncMap and ShardedMap under a concurrent load of 80% reads and 20% writes.
+
+type concurrentMap interface {
+	Get(Int) (Int, bool)
+	Put(Int, Int)
+}
+
+func benchmarkConcurrentMap(b *testing.B, m concurrentMap) {
+	for i := 0; i < 1000; i++ {
+		m.Put(Int(i), Int(i))
+	}
+	b.SetParallelism(8)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := Int(i % 1000)
+			if i%5 == 0 {
+				m.Put(k, k)
+			} else {
+				m.Get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapConcurrent(b *testing.B) {
+	benchmarkConcurrentMap(b, &SyncMap[Int, Int]{})
+}
+
+func BenchmarkShardedMapConcurrent(b *testing.B) {
+	benchmarkConcurrentMap(b, NewShardedMap[Int, Int]())
+}
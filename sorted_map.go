@@ -0,0 +1,258 @@
+package hashmap
+
+import "iter"
+
+type sortedNode[K any, V any] struct {
+	key         K
+	value       V
+	left, right *sortedNode[K, V]
+	height      int
+	size        int
+}
+
+func (n *sortedNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *sortedNode[K, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *sortedNode[K, V]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *sortedNode[K, V]) rotateLeft() *sortedNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *sortedNode[K, V]) rotateRight() *sortedNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// balance restores the AVL invariant at n after one of its subtrees changed height by one.
+func (n *sortedNode[K, V]) balance() *sortedNode[K, V] {
+	n.update()
+	switch bf := n.left.getHeight() - n.right.getHeight(); {
+	case bf > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case bf < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *sortedNode[K, V]) removeMin() *sortedNode[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = n.left.removeMin()
+	return n.balance()
+}
+
+// SortedMap is a map that keeps its keys sorted by a comparison function.
+// It is implemented as an AVL tree, so Get, Put, Remove and Rank are O(log n).
+// It is not thread-safe.
+// A SortedMap must be created with NewSortedMap.
+type SortedMap[K Comparable[K], V any] struct {
+	less func(K, K) bool
+	root *sortedNode[K, V]
+}
+
+// NewSortedMap returns a new empty map that orders keys by less.
+func NewSortedMap[K Comparable[K], V any](less func(K, K) bool) *SortedMap[K, V] {
+	return &SortedMap[K, V]{less: less}
+}
+
+// Size returns the number of elements in the map.
+func (m *SortedMap[K, V]) Size() int {
+	return m.root.getSize()
+}
+
+// Get returns the value associated with the given key.
+// The second return value indicates if the key was found.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	n := m.root
+	for n != nil {
+		switch {
+		case m.less(key, n.key):
+			n = n.left
+		case m.less(n.key, key):
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Put adds the given key/value pair to the map.
+// If the key already exists, the value is updated.
+func (m *SortedMap[K, V]) Put(key K, value V) {
+	m.root = m.put(m.root, key, value)
+}
+
+func (m *SortedMap[K, V]) put(n *sortedNode[K, V], key K, value V) *sortedNode[K, V] {
+	switch {
+	case n == nil:
+		return &sortedNode[K, V]{key: key, value: value, height: 1, size: 1}
+	case m.less(key, n.key):
+		n.left = m.put(n.left, key, value)
+	case m.less(n.key, key):
+		n.right = m.put(n.right, key, value)
+	default:
+		n.value = value
+		return n
+	}
+	return n.balance()
+}
+
+// Remove removes the given key from the map.
+func (m *SortedMap[K, V]) Remove(key K) {
+	m.root = m.remove(m.root, key)
+}
+
+func (m *SortedMap[K, V]) remove(n *sortedNode[K, V], key K) *sortedNode[K, V] {
+	switch {
+	case n == nil:
+		return nil
+	case m.less(key, n.key):
+		n.left = m.remove(n.left, key)
+	case m.less(n.key, key):
+		n.right = m.remove(n.right, key)
+	default:
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		successor.right = n.right.removeMin()
+		successor.left = n.left
+		n = successor
+	}
+	return n.balance()
+}
+
+// Min returns the key/value pair with the smallest key.
+// The third return value is false if the map is empty.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the key/value pair with the largest key.
+// The third return value is false if the map is empty.
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Rank returns the number of keys in the map that are less than the given key.
+func (m *SortedMap[K, V]) Rank(key K) int {
+	rank := 0
+	n := m.root
+	for n != nil {
+		if m.less(n.key, key) {
+			rank += n.left.getSize() + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return rank
+}
+
+// ForEach calls the given function for each key/value pair in the map in ascending key order.
+// If f returns an error, iteration stops and ForEach returns that error,
+// unless it is ErrStopIteration, in which case ForEach returns nil.
+func (m *SortedMap[K, V]) ForEach(f func(K, V) error) error {
+	var err error
+	m.walk(m.root, func(k K, v V) bool {
+		err = f(k, v)
+		return err == nil
+	})
+	if err == ErrStopIteration {
+		return nil
+	}
+	return err
+}
+
+// Range returns an iterator over the key/value pairs with keys in [lo, hi) in ascending key order.
+// The map must not be modified during iteration.
+func (m *SortedMap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.walkRange(m.root, lo, hi, yield)
+	}
+}
+
+// walk calls f for each node under n in order until f returns false.
+func (m *SortedMap[K, V]) walk(n *sortedNode[K, V], f func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return m.walk(n.left, f) && f(n.key, n.value) && m.walk(n.right, f)
+}
+
+// walkRange is like walk, but skips subtrees with keys outside [lo, hi).
+func (m *SortedMap[K, V]) walkRange(n *sortedNode[K, V], lo, hi K, f func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := !m.less(n.key, lo)
+	belowHi := m.less(n.key, hi)
+	if aboveLo && !m.walkRange(n.left, lo, hi, f) {
+		return false
+	}
+	if aboveLo && belowHi && !f(n.key, n.value) {
+		return false
+	}
+	if belowHi {
+		return m.walkRange(n.right, lo, hi, f)
+	}
+	return true
+}
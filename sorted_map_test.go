@@ -0,0 +1,137 @@
+package hashmap
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func intLess(a, b Int) bool {
+	return a < b
+}
+
+func sortedKeys(m *SortedMap[Int, Int]) []Int {
+	var keys []Int
+	m.ForEach(func(k Int, v Int) error {
+		keys = append(keys, k)
+		return nil
+	})
+	return keys
+}
+
+// checkAVL verifies the height, size and ordering invariants of the tree under n.
+func checkAVL(t *testing.T, n *sortedNode[Int, Int]) (height, size int) {
+	if n == nil {
+		return 0, 0
+	}
+	lh, ls := checkAVL(t, n.left)
+	rh, rs := checkAVL(t, n.right)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Fatalf("unbalanced node %d: heights %d and %d", n.key, lh, rh)
+	}
+	if n.height != 1+max(lh, rh) || n.size != 1+ls+rs {
+		t.Fatalf("stale height or size at node %d", n.key)
+	}
+	return n.height, n.size
+}
+
+func TestSortedMap(t *testing.T) {
+	m := NewSortedMap[Int, Int](intLess)
+	if _, _, ok := m.Min(); ok {
+		t.Errorf("expected no minimum in empty map")
+	}
+	r := rand.New(rand.NewSource(1))
+	native := map[Int]Int{}
+	for i := 0; i < 1000; i++ {
+		k := Int(r.Intn(500))
+		if r.Intn(3) == 0 {
+			m.Remove(k)
+			delete(native, k)
+		} else {
+			m.Put(k, k*2)
+			native[k] = k * 2
+		}
+		checkAVL(t, m.root)
+	}
+	if m.Size() != len(native) {
+		t.Errorf("expected size %d, got %d", len(native), m.Size())
+	}
+	expected := make([]Int, 0, len(native))
+	for k, v := range native {
+		expected = append(expected, k)
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Errorf("expected value %d for key %d, got %d", v, k, got)
+		}
+	}
+	sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+	if keys := sortedKeys(m); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected keys in sorted order")
+	}
+	if k, _, _ := m.Min(); k != expected[0] {
+		t.Errorf("expected minimum %d, got %d", expected[0], k)
+	}
+	if k, _, _ := m.Max(); k != expected[len(expected)-1] {
+		t.Errorf("expected maximum %d, got %d", expected[len(expected)-1], k)
+	}
+	for i, k := range expected {
+		if rank := m.Rank(k); rank != i {
+			t.Errorf("expected rank %d for key %d, got %d", i, k, rank)
+		}
+	}
+}
+
+func TestSortedMapRange(t *testing.T) {
+	m := NewSortedMap[Int, Int](intLess)
+	for i := 0; i < 20; i += 2 {
+		m.Put(Int(i), Int(i))
+	}
+	var keys []Int
+	for k := range m.Range(3, 11) {
+		keys = append(keys, k)
+	}
+	if !reflect.DeepEqual(keys, []Int{4, 6, 8, 10}) {
+		t.Errorf("expected [4 6 8 10], got %v", keys)
+	}
+	keys = nil
+	for k := range m.Range(4, 10) {
+		keys = append(keys, k)
+		if len(keys) == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(keys, []Int{4, 6}) {
+		t.Errorf("expected [4 6], got %v", keys)
+	}
+	if m.Rank(5) != 3 || m.Rank(-1) != 0 || m.Rank(100) != 10 {
+		t.Errorf("unexpected rank of absent keys")
+	}
+}
+
+// The benchmarks below compare iterating in sorted order with a SortedMap
+// and with a Map whose keys are sorted after insertion.
+
+func BenchmarkSortedMap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m := NewSortedMap[Int, Int](intLess)
+		for j := 0; j < 1000; j++ {
+			m.Put(Int(j*7919%1000), Int(j))
+		}
+		m.ForEach(func(k Int, v Int) error {
+			return nil
+		})
+	}
+}
+
+func BenchmarkMapSorted(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m := Map[Int, Int]{}
+		for j := 0; j < 1000; j++ {
+			m.Put(Int(j*7919%1000), Int(j))
+		}
+		for _, k := range slices.Sorted(m.Keys()) {
+			m.Get(k)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package hashmap
+
+import "iter"
+
+// SortedSet is a set that keeps its keys sorted by a comparison function.
+// It is a SortedMap without values, so Add, Remove, Contains and Rank are O(log n).
+// It is not thread-safe.
+// A SortedSet must be created with NewSortedSet.
+type SortedSet[K Comparable[K]] struct {
+	m *SortedMap[K, struct{}]
+}
+
+// NewSortedSet returns a new empty set that orders keys by less.
+func NewSortedSet[K Comparable[K]](less func(K, K) bool) *SortedSet[K] {
+	return &SortedSet[K]{NewSortedMap[K, struct{}](less)}
+}
+
+// Size returns the number of elements in the set.
+func (s *SortedSet[K]) Size() int {
+	return s.m.Size()
+}
+
+// Contains returns true if the set contains the given key.
+func (s *SortedSet[K]) Contains(key K) bool {
+	_, ok := s.m.Get(key)
+	return ok
+}
+
+// Add adds the given key to the set.
+func (s *SortedSet[K]) Add(key K) {
+	s.m.Put(key, struct{}{})
+}
+
+// Remove removes the given key from the set.
+func (s *SortedSet[K]) Remove(key K) {
+	s.m.Remove(key)
+}
+
+// Min returns the smallest key.
+// The second return value is false if the set is empty.
+func (s *SortedSet[K]) Min() (K, bool) {
+	k, _, ok := s.m.Min()
+	return k, ok
+}
+
+// Max returns the largest key.
+// The second return value is false if the set is empty.
+func (s *SortedSet[K]) Max() (K, bool) {
+	k, _, ok := s.m.Max()
+	return k, ok
+}
+
+// Rank returns the number of keys in the set that are less than the given key.
+func (s *SortedSet[K]) Rank(key K) int {
+	return s.m.Rank(key)
+}
+
+// ForEach calls the given function for each key in the set in ascending order.
+// If f returns an error, iteration stops and ForEach returns that error,
+// unless it is ErrStopIteration, in which case ForEach returns nil.
+func (s *SortedSet[K]) ForEach(f func(K) error) error {
+	return s.m.ForEach(func(k K, _ struct{}) error {
+		return f(k)
+	})
+}
+
+// Range returns an iterator over the keys in [lo, hi) in ascending order.
+// The set must not be modified during iteration.
+func (s *SortedSet[K]) Range(lo, hi K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s.m.Range(lo, hi) {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
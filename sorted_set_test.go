@@ -0,0 +1,71 @@
+package hashmap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSortedSet(t *testing.T) {
+	s := NewSortedSet[Int](intLess)
+	if _, ok := s.Min(); ok {
+		t.Errorf("expected Min of empty set to fail")
+	}
+	for _, k := range []Int{5, 3, 8, 1, 9, 3} {
+		s.Add(k)
+	}
+	if s.Size() != 5 {
+		t.Errorf("expected size 5, got %d", s.Size())
+	}
+	if !s.Contains(8) || s.Contains(4) {
+		t.Errorf("unexpected Contains result")
+	}
+	if k, ok := s.Min(); !ok || k != 1 {
+		t.Errorf("expected min 1, got %d", k)
+	}
+	if k, ok := s.Max(); !ok || k != 9 {
+		t.Errorf("expected max 9, got %d", k)
+	}
+	if s.Rank(5) != 2 || s.Rank(6) != 3 || s.Rank(0) != 0 {
+		t.Errorf("unexpected ranks %d %d %d", s.Rank(5), s.Rank(6), s.Rank(0))
+	}
+	var keys []Int
+	s.ForEach(func(k Int) error {
+		keys = append(keys, k)
+		return nil
+	})
+	if !slices.Equal(keys, []Int{1, 3, 5, 8, 9}) {
+		t.Errorf("expected [1 3 5 8 9], got %v", keys)
+	}
+	if r := slices.Collect(s.Range(3, 9)); !slices.Equal(r, []Int{3, 5, 8}) {
+		t.Errorf("expected [3 5 8], got %v", r)
+	}
+	s.Remove(3)
+	s.Remove(4)
+	if s.Size() != 4 || s.Contains(3) {
+		t.Errorf("expected 3 to be removed, got size %d", s.Size())
+	}
+	errTest := errors.New("test")
+	if err := s.ForEach(func(k Int) error { return errTest }); err != errTest {
+		t.Errorf("expected test error, got %v", err)
+	}
+}
+
+// The benchmarks below show that Contains grows logarithmically with the size of the set.
+
+func BenchmarkSortedSetContains(b *testing.B) {
+	for _, n := range []int{1000, 100000} {
+		s := NewSortedSet[Int](intLess)
+		for j := 0; j < n; j++ {
+			s.Add(Int(j * 7919 % n))
+		}
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !s.Contains(Int(i % n)) {
+					b.Fatal("expected to find key")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package hashmap
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements sql.Scanner.
+// It replaces the contents of the map with the JSON in src, which must be a []byte or string
+// in a form accepted by UnmarshalJSON, such as an object for Map[String, String].
+// A NULL value leaves the map empty. If Scan returns an error, the map is unchanged.
+func (m *Map[K, V]) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		m.Clear()
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("hashmap: cannot scan %T into Map", src)
+	}
+	r := m.newLike(0)
+	if err := r.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*m = *r
+	return nil
+}
+
+// Value implements driver.Valuer.
+// It returns the map encoded by MarshalJSON.
+// Value has a value receiver, so both Map and *Map are Valuers,
+// and database/sql stores a nil *Map as NULL.
+func (m Map[K, V]) Value() (driver.Value, error) {
+	return m.MarshalJSON()
+}
@@ -0,0 +1,56 @@
+package hashmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+// The checks below make sure Map can be passed to Rows.Scan and used as a query argument.
+var (
+	_ sql.Scanner   = (*Map[String, String])(nil)
+	_ driver.Valuer = (*Map[String, String])(nil)
+	_ driver.Valuer = Map[String, String]{}
+)
+
+func TestMapSQL(t *testing.T) {
+	m := Map[String, String]{}
+	m.Put("a", "1")
+	m.Put("b", "2")
+	v, err := m.Value()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if s := string(v.([]byte)); s != `{"a":"1","b":"2"}` {
+		t.Errorf(`expected {"a":"1","b":"2"}, got %s`, s)
+	}
+
+	// Scan is called with whatever the driver returns for the column.
+	for _, src := range []any{v, string(v.([]byte))} {
+		r := Map[String, String]{}
+		r.Put("stale", "x")
+		if err := r.Scan(src); err != nil {
+			t.Errorf("expected no error scanning %T, got %v", src, err)
+		}
+		if !r.Equals(&m) {
+			t.Errorf("expected %v, got %v", &m, &r)
+		}
+	}
+	// A failed Scan leaves the map as it was.
+	for _, src := range []any{42, "not json", `{"c":"3","d":4}`} {
+		c := m.Copy()
+		if err := c.Scan(src); err == nil {
+			t.Errorf("expected error scanning %#v", src)
+		}
+		if !c.Equals(&m) {
+			t.Errorf("expected failed scan of %#v to leave %v, got %v", src, &m, c)
+		}
+	}
+	if err := m.Scan(nil); err != nil || m.Size() != 0 {
+		t.Errorf("expected NULL to leave an empty map, got %v and %v", &m, err)
+	}
+	var nilMap *Map[String, String]
+	if v, err := driver.DefaultParameterConverter.ConvertValue(nilMap); err != nil || v != nil {
+		t.Errorf("expected a nil *Map to be stored as NULL, got %v and %v", v, err)
+	}
+}
@@ -0,0 +1,65 @@
+package hashmap
+
+import "sync"
+
+// SyncMap is a Map protected by a sync.RWMutex.
+// It is safe for concurrent use by multiple goroutines.
+// The zero value is an empty map ready to use.
+// A SyncMap must not be copied after first use.
+type SyncMap[K Comparable[K], V any] struct {
+	mu sync.RWMutex
+	m  Map[K, V]
+}
+
+// Size returns the number of elements in the map.
+func (s *SyncMap[K, V]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m.Size()
+}
+
+// Get returns the value associated with the given key.
+// The second return value indicates if the key was found.
+func (s *SyncMap[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m.Get(key)
+}
+
+// Put adds the given key/value pair to the map.
+// If the key already exists, the value is updated.
+func (s *SyncMap[K, V]) Put(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m.Put(key, value)
+}
+
+// Remove removes the given key from the map.
+func (s *SyncMap[K, V]) Remove(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m.Remove(key)
+}
+
+// Clear removes all elements from the map.
+func (s *SyncMap[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m.Clear()
+}
+
+// ForEach calls the given function for each key/value pair in the map.
+// The read lock is held for the whole iteration, so f must not call
+// mutating methods on the same map or it will deadlock.
+func (s *SyncMap[K, V]) ForEach(f func(K, V) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m.ForEach(f)
+}
+
+// Copy returns a copy of the map.
+func (s *SyncMap[K, V]) Copy() *SyncMap[K, V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &SyncMap[K, V]{m: *s.m.Copy()}
+}
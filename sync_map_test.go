@@ -0,0 +1,52 @@
+package hashmap
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncMap(t *testing.T) {
+	var m SyncMap[Int, Int]
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				k := Int(g*100 + i)
+				m.Put(k, k*2)
+				if v, ok := m.Get(k); !ok || v != k*2 {
+					t.Errorf("expected value %d, got %d", k*2, v)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Size() != 800 {
+		t.Errorf("expected size 800, got %d", m.Size())
+	}
+
+	c := m.Copy()
+	for i := 0; i < 800; i += 2 {
+		m.Remove(Int(i))
+	}
+	if m.Size() != 400 {
+		t.Errorf("expected size 400, got %d", m.Size())
+	}
+	if c.Size() != 800 {
+		t.Errorf("expected copy size 800, got %d", c.Size())
+	}
+
+	sum := 0
+	m.ForEach(func(k Int, v Int) error {
+		sum++
+		return nil
+	})
+	if sum != 400 {
+		t.Errorf("expected 400 iterations, got %d", sum)
+	}
+	m.Clear()
+	if m.Size() != 0 {
+		t.Errorf("expected size 0, got %d", m.Size())
+	}
+}
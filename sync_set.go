@@ -0,0 +1,119 @@
+package hashmap
+
+import "sync"
+
+// SyncSet is a Set protected by a sync.RWMutex.
+// It is safe for concurrent use by multiple goroutines.
+// The zero value is an empty set ready to use.
+// A SyncSet must not be copied after first use.
+type SyncSet[K Comparable[K]] struct {
+	mu sync.RWMutex
+	s  Set[K]
+}
+
+// Size returns the number of elements in the set.
+func (s *SyncSet[K]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Size()
+}
+
+// Contains returns true if the set contains the given key.
+func (s *SyncSet[K]) Contains(key K) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Contains(key)
+}
+
+// Add adds the given key to the set.
+func (s *SyncSet[K]) Add(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Add(key)
+}
+
+// Remove removes the given key from the set.
+func (s *SyncSet[K]) Remove(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Remove(key)
+}
+
+// ForEach calls the given function for each key in the set.
+// The read lock is held for the whole iteration, so f must not call
+// mutating methods on the same set or it will deadlock.
+func (s *SyncSet[K]) ForEach(f func(K) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.ForEach(f)
+}
+
+// Copy returns a copy of the set.
+func (s *SyncSet[K]) Copy() *SyncSet[K] {
+	return &SyncSet[K]{s: *s.snapshot()}
+}
+
+// snapshot returns a copy of the underlying set taken under the read lock.
+// Operations on two sets work on a snapshot of the second one,
+// so that no goroutine ever holds the locks of two sets at once.
+func (s *SyncSet[K]) snapshot() *Set[K] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Copy()
+}
+
+// Union returns a new set with all the elements in both sets.
+func (s *SyncSet[K]) Union(t *SyncSet[K]) *SyncSet[K] {
+	u := t.snapshot()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &SyncSet[K]{s: *s.s.Union(u)}
+}
+
+// Intersection returns a new set with the elements that are in both sets.
+func (s *SyncSet[K]) Intersection(t *SyncSet[K]) *SyncSet[K] {
+	u := t.snapshot()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &SyncSet[K]{s: *s.s.Intersection(u)}
+}
+
+// Difference returns a new set with the elements that are in the set but not in the given set.
+func (s *SyncSet[K]) Difference(t *SyncSet[K]) *SyncSet[K] {
+	u := t.snapshot()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &SyncSet[K]{s: *s.s.Difference(u)}
+}
+
+// SymmetricDifference returns a new set with the elements that are in exactly one of the sets.
+func (s *SyncSet[K]) SymmetricDifference(t *SyncSet[K]) *SyncSet[K] {
+	u := t.snapshot()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &SyncSet[K]{s: *s.s.SymmetricDifference(u)}
+}
+
+// IsSubset returns true if the set is a subset of the given set.
+func (s *SyncSet[K]) IsSubset(t *SyncSet[K]) bool {
+	u := t.snapshot()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsSubset(u)
+}
+
+// IsSuperset returns true if the given set is a subset of the set.
+func (s *SyncSet[K]) IsSuperset(t *SyncSet[K]) bool {
+	u := t.snapshot()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsSuperset(u)
+}
+
+// IsDisjoint returns true if the intersection of the set and the given set is empty.
+func (s *SyncSet[K]) IsDisjoint(t *SyncSet[K]) bool {
+	u := t.snapshot()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsDisjoint(u)
+}
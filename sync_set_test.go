@@ -0,0 +1,73 @@
+package hashmap
+
+import (
+	"sync"
+	"testing"
+)
+
+func syncIntSet(is ...int) *SyncSet[Int] {
+	s := new(SyncSet[Int])
+	for _, i := range is {
+		s.Add(Int(i))
+	}
+	return s
+}
+
+func TestSyncSet(t *testing.T) {
+	var s SyncSet[Int]
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Add(Int(g*100 + i))
+				if !s.Contains(Int(g*100 + i)) {
+					t.Errorf("expected to find key %d", g*100+i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if s.Size() != 800 {
+		t.Errorf("expected size 800, got %d", s.Size())
+	}
+	for i := 0; i < 800; i += 2 {
+		s.Remove(Int(i))
+	}
+	if s.Size() != 400 {
+		t.Errorf("expected size 400, got %d", s.Size())
+	}
+	if c := s.Copy(); c.Size() != 400 {
+		t.Errorf("expected copy size 400, got %d", c.Size())
+	}
+}
+
+func TestSyncSetOperations(t *testing.T) {
+	a := syncIntSet(1, 2, 3)
+	b := syncIntSet(2, 3, 4)
+	if !a.Union(b).s.Equals(intSet(1, 2, 3, 4)) {
+		t.Errorf("unexpected union")
+	}
+	if !a.Intersection(b).s.Equals(intSet(2, 3)) {
+		t.Errorf("unexpected intersection")
+	}
+	if !a.Difference(b).s.Equals(intSet(1)) {
+		t.Errorf("unexpected difference")
+	}
+	if !a.SymmetricDifference(b).s.Equals(intSet(1, 4)) {
+		t.Errorf("unexpected symmetric difference")
+	}
+	if !a.SymmetricDifference(a).s.Equals(intSet()) {
+		t.Errorf("expected symmetric difference with itself to be empty")
+	}
+	if a.IsSubset(b) || !syncIntSet(2).IsSubset(b) {
+		t.Errorf("unexpected subset result")
+	}
+	if a.IsSuperset(b) || !b.IsSuperset(syncIntSet(2, 4)) {
+		t.Errorf("unexpected superset result")
+	}
+	if a.IsDisjoint(b) || !a.IsDisjoint(syncIntSet(5)) {
+		t.Errorf("unexpected disjoint result")
+	}
+}
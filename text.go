@@ -0,0 +1,163 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+var textEscaper = strings.NewReplacer("%", "%25", "=", "%3D", "\n", "%0A")
+
+func marshalTextElement(v any) (string, error) {
+	tm, ok := v.(encoding.TextMarshaler)
+	if !ok {
+		return "", &UnsupportedTypeError{fmt.Sprintf("%T", v), "encoding.TextMarshaler"}
+	}
+	text, err := tm.MarshalText()
+	if err != nil {
+		return "", err
+	}
+	return textEscaper.Replace(string(text)), nil
+}
+
+func unmarshalTextElement(s string, v any) error {
+	tu, ok := v.(encoding.TextUnmarshaler)
+	if !ok {
+		return &UnsupportedTypeError{fmt.Sprintf("%T", v), "encoding.TextUnmarshaler"}
+	}
+	text, err := url.PathUnescape(s)
+	if err != nil {
+		return err
+	}
+	return tu.UnmarshalText([]byte(text))
+}
+
+// textLines splits text into newline-terminated lines.
+func textLines(text []byte) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.Split(string(text), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The map is encoded as newline-terminated key=value lines sorted by key,
+// with '%', '=' and newlines in keys and values percent-encoded.
+// K and V must implement encoding.TextMarshaler, as String does.
+func (m *Map[K, V]) MarshalText() ([]byte, error) {
+	lines := make([]string, 0, m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			k, err := marshalTextElement(entry.key)
+			if err != nil {
+				return nil, err
+			}
+			v, err := marshalTextElement(entry.value)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, k+"="+v)
+		}
+	}
+	sort.Strings(lines)
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes text produced by MarshalText and adds the entries to the map.
+// *K and *V must implement encoding.TextUnmarshaler, as *String does.
+func (m *Map[K, V]) UnmarshalText(text []byte) error {
+	for _, line := range textLines(text) {
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return errors.New("hashmap: expected key=value line")
+		}
+		var key K
+		var value V
+		if err := unmarshalTextElement(k, &key); err != nil {
+			return err
+		}
+		if err := unmarshalTextElement(v, &value); err != nil {
+			return err
+		}
+		m.Put(key, value)
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The set is encoded as sorted newline-terminated lines,
+// with '%', '=' and newlines in keys percent-encoded.
+// K must implement encoding.TextMarshaler, as String does.
+func (s *Set[K]) MarshalText() ([]byte, error) {
+	lines := make([]string, 0, s.size)
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			k, err := marshalTextElement(entry.key)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, k)
+		}
+	}
+	sort.Strings(lines)
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes text produced by MarshalText and adds the keys to the set.
+// *K must implement encoding.TextUnmarshaler, as *String does.
+func (s *Set[K]) UnmarshalText(text []byte) error {
+	for _, line := range textLines(text) {
+		var key K
+		if err := unmarshalTextElement(line, &key); err != nil {
+			return err
+		}
+		s.Add(key)
+	}
+	return nil
+}
+
+// String implements fmt.Stringer.
+// The map is formatted as {key:value ...} with entries sorted by their formatted text.
+func (m *Map[K, V]) String() string {
+	items := make([]string, 0, m.size)
+	for _, entry := range m.entries {
+		if entry.hash1 != 0 {
+			items = append(items, fmt.Sprintf("%v:%v", entry.key, entry.value))
+		}
+	}
+	sort.Strings(items)
+	return "{" + strings.Join(items, " ") + "}"
+}
+
+// String implements fmt.Stringer.
+// The set is formatted as {key ...} with keys sorted by their formatted text.
+func (s *Set[K]) String() string {
+	items := make([]string, 0, s.size)
+	for _, entry := range s.entries {
+		if entry.hash1 != 0 {
+			items = append(items, fmt.Sprintf("%v", entry.key))
+		}
+	}
+	sort.Strings(items)
+	return "{" + strings.Join(items, " ") + "}"
+}
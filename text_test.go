@@ -0,0 +1,102 @@
+package hashmap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMapText(t *testing.T) {
+	m := Map[String, String]{}
+	m.Put("b", "2")
+	m.Put("a", "1")
+	m.Put("x=y", "line1\nline2 100%")
+	text, err := m.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "a=1\nb=2\nx%3Dy=line1%0Aline2 100%25\n"
+	if string(text) != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+	var r Map[String, String]
+	if err := r.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equals(&m) {
+		t.Errorf("expected round trip to preserve the map")
+	}
+
+	if err := r.UnmarshalText([]byte("novalue\n")); err == nil {
+		t.Errorf("expected error for line without '='")
+	}
+	var ute *UnsupportedTypeError
+	if _, err := intMap(1, 1).MarshalText(); !errors.As(err, &ute) {
+		t.Errorf("expected UnsupportedTypeError, got %v", err)
+	}
+}
+
+func TestSetText(t *testing.T) {
+	s := Set[String]{}
+	s.Add("b")
+	s.Add("a\nc")
+	s.Add("")
+	text, err := s.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "\na%0Ac\nb\n" {
+		t.Errorf("unexpected text %q", text)
+	}
+	var r Set[String]
+	if err := r.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equals(&s) {
+		t.Errorf("expected round trip to preserve the set")
+	}
+	var e Set[String]
+	if err := e.UnmarshalText(nil); err != nil || e.Size() != 0 {
+		t.Errorf("expected empty text to decode to empty set")
+	}
+}
+
+func TestMapString(t *testing.T) {
+	tests := []struct {
+		m *Map[Int, Int]
+		s string
+	}{
+		{intMap(), "{}"},
+		{intMap(1, 10), "{1:10}"},
+		{intMap(3, 30, 1, 10, 2, 20), "{1:10 2:20 3:30}"},
+	}
+	for _, test := range tests {
+		if s := test.m.String(); s != test.s {
+			t.Errorf("expected %q, got %q", test.s, s)
+		}
+	}
+	if s := fmt.Sprint(intMap(1, 10)); s != "{1:10}" {
+		t.Errorf("expected fmt to use String, got %q", s)
+	}
+}
+
+func TestSetString(t *testing.T) {
+	tests := []struct {
+		s   *Set[Int]
+		str string
+	}{
+		{intSet(), "{}"},
+		{&Set[Int]{}, "{}"},
+		{intSet(3, 1, 2), "{1 2 3}"},
+	}
+	for _, test := range tests {
+		if s := test.s.String(); s != test.str {
+			t.Errorf("expected %q, got %q", test.str, s)
+		}
+	}
+	nested := NewSet[*Set[Int]](0)
+	nested.Add(intSet(2, 1))
+	if s := nested.String(); s != "{{1 2}}" {
+		t.Errorf("expected {{1 2}}, got %q", s)
+	}
+}
@@ -0,0 +1,41 @@
+package hashmap
+
+// Pair is a composite key of two Comparable values.
+type Pair[A Comparable[A], B Comparable[B]] struct {
+	First  A
+	Second B
+}
+
+// NewPair returns a Pair of the given values.
+func NewPair[A Comparable[A], B Comparable[B]](a A, b B) Pair[A, B] {
+	return Pair[A, B]{a, b}
+}
+
+func (p Pair[A, B]) Hash() uint64 {
+	return hash64bits(p.First.Hash() ^ (p.Second.Hash() * 2654435761))
+}
+
+func (p Pair[A, B]) Equals(other Pair[A, B]) bool {
+	return p.First.Equals(other.First) && p.Second.Equals(other.Second)
+}
+
+// Tuple3 is a composite key of three Comparable values.
+type Tuple3[A Comparable[A], B Comparable[B], C Comparable[C]] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// NewTuple3 returns a Tuple3 of the given values.
+func NewTuple3[A Comparable[A], B Comparable[B], C Comparable[C]](a A, b B, c C) Tuple3[A, B, C] {
+	return Tuple3[A, B, C]{a, b, c}
+}
+
+func (t Tuple3[A, B, C]) Hash() uint64 {
+	// Each position uses a different multiplier so that permutations hash differently.
+	return hash64bits(t.First.Hash()*0x9e3779b97f4a7c15 ^ t.Second.Hash()*0xc2b2ae3d27d4eb4f ^ t.Third.Hash()*0x165667b19e3779f9)
+}
+
+func (t Tuple3[A, B, C]) Equals(other Tuple3[A, B, C]) bool {
+	return t.First.Equals(other.First) && t.Second.Equals(other.Second) && t.Third.Equals(other.Third)
+}
@@ -0,0 +1,51 @@
+package hashmap
+
+import "testing"
+
+func TestPair(t *testing.T) {
+	m := Map[Pair[String, Int], Float64]{}
+	m.Put(NewPair[String, Int]("a", 1), 1.5)
+	m.Put(NewPair[String, Int]("a", 2), 2.5)
+	m.Put(NewPair[String, Int]("b", 1), 3.5)
+	if m.Size() != 3 {
+		t.Errorf("expected size 3, got %d", m.Size())
+	}
+	if v, ok := m.Get(NewPair[String, Int]("a", 2)); !ok || v != 2.5 {
+		t.Errorf("expected value 2.5, got %v", v)
+	}
+	if _, ok := m.Get(NewPair[String, Int]("b", 2)); ok {
+		t.Errorf("expected to not find (b, 2)")
+	}
+	if NewPair[Int, Int](1, 2).Hash() == NewPair[Int, Int](2, 1).Hash() {
+		t.Errorf("expected swapped pairs to hash differently")
+	}
+}
+
+func TestTuple3(t *testing.T) {
+	perms := []Tuple3[Int, Int, Int]{
+		NewTuple3[Int, Int, Int](1, 2, 3),
+		NewTuple3[Int, Int, Int](1, 3, 2),
+		NewTuple3[Int, Int, Int](2, 1, 3),
+		NewTuple3[Int, Int, Int](2, 3, 1),
+		NewTuple3[Int, Int, Int](3, 1, 2),
+		NewTuple3[Int, Int, Int](3, 2, 1),
+	}
+	hashes := map[uint64]bool{}
+	s := Set[Tuple3[Int, Int, Int]]{}
+	for _, p := range perms {
+		hashes[p.Hash()] = true
+		s.Add(p)
+	}
+	if len(hashes) != len(perms) {
+		t.Errorf("expected permutations to hash differently, got %d distinct hashes", len(hashes))
+	}
+	if s.Size() != len(perms) {
+		t.Errorf("expected size %d, got %d", len(perms), s.Size())
+	}
+	if !s.Contains(NewTuple3[Int, Int, Int](2, 3, 1)) {
+		t.Errorf("expected to find (2, 3, 1)")
+	}
+	if s.Contains(NewTuple3[Int, Int, Int](1, 1, 1)) {
+		t.Errorf("expected to not find (1, 1, 1)")
+	}
+}
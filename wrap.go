@@ -0,0 +1,118 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// Wrapped is a wrapper around any Go comparable type that implements the Comparable interface.
+// Equality is Go's ==. Common basic types are hashed with the same helpers as the
+// matching wrapper types; other types, such as structs, are hashed field by field using reflection.
+type Wrapped[T comparable] struct {
+	V T
+}
+
+// W returns v wrapped as a Comparable.
+func W[T comparable](v T) Wrapped[T] {
+	return Wrapped[T]{v}
+}
+
+func (w Wrapped[T]) Hash() uint64 {
+	switch v := any(w.V).(type) {
+	case int:
+		return Int(v).Hash()
+	case int64:
+		return Int64(v).Hash()
+	case int32:
+		return Int32(v).Hash()
+	case int16:
+		return Int16(v).Hash()
+	case int8:
+		return Int8(v).Hash()
+	case uint:
+		return Uint(v).Hash()
+	case uint64:
+		return Uint64(v).Hash()
+	case uint32:
+		return Uint32(v).Hash()
+	case uint16:
+		return Uint16(v).Hash()
+	case uint8:
+		return Uint8(v).Hash()
+	case string:
+		return String(v).Hash()
+	case bool:
+		return Bool(v).Hash()
+	}
+	h := maphash.Hash{}
+	h.SetSeed(seed)
+	hashValue(&h, reflect.ValueOf(&w.V).Elem())
+	return h.Sum64()
+}
+
+func (w Wrapped[T]) Equals(other Wrapped[T]) bool {
+	return w.V == other.V
+}
+
+func writeUint64(h *maphash.Hash, u uint64) {
+	h.WriteByte(byte(u))
+	h.WriteByte(byte(u >> 8))
+	h.WriteByte(byte(u >> 16))
+	h.WriteByte(byte(u >> 24))
+	h.WriteByte(byte(u >> 32))
+	h.WriteByte(byte(u >> 40))
+	h.WriteByte(byte(u >> 48))
+	h.WriteByte(byte(u >> 56))
+}
+
+// hashValue writes v to h so that values equal under == write the same bytes.
+func hashValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint64(h, floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeUint64(h, floatBits(real(c)))
+		writeUint64(h, floatBits(imag(c)))
+	case reflect.String:
+		// The length keeps field boundaries apart, so {"ab", ""} and {"a", "b"} differ.
+		writeUint64(h, uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// Blank fields are ignored by ==.
+			if v.Type().Field(i).Name != "_" {
+				hashValue(h, v.Field(i))
+			}
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			hashValue(h, v.Elem())
+		}
+	}
+}
+
+// floatBits returns the bits of f, treating -0 as 0 because they are equal under ==.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
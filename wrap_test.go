@@ -0,0 +1,54 @@
+package hashmap
+
+import "testing"
+
+func TestWrapped(t *testing.T) {
+	type point struct {
+		X, Y int
+		Name string
+	}
+	m := Map[Wrapped[point], Int]{}
+	m.Put(W(point{1, 2, "a"}), 1)
+	m.Put(W(point{2, 1, "a"}), 2)
+	m.Put(W(point{1, 2, "b"}), 3)
+	if m.Size() != 3 {
+		t.Errorf("expected size 3, got %d", m.Size())
+	}
+	if v, ok := m.Get(W(point{2, 1, "a"})); !ok || v != 2 {
+		t.Errorf("expected value 2, got %d", v)
+	}
+	if _, ok := m.Get(W(point{2, 2, "a"})); ok {
+		t.Errorf("expected to not find point {2 2 a}")
+	}
+
+	if W(42).Hash() != Int(42).Hash() {
+		t.Errorf("expected wrapped int to hash like Int")
+	}
+	if W("a").Hash() != String("a").Hash() {
+		t.Errorf("expected wrapped string to hash like String")
+	}
+
+	type floats struct{ F float64 }
+	negZero := 0.0
+	negZero = -negZero
+	if !W(floats{0}).Equals(W(floats{negZero})) || W(floats{0}).Hash() != W(floats{negZero}).Hash() {
+		t.Errorf("expected 0 and -0 to be equal and hash equally")
+	}
+
+	x, y := 1, 1
+	if W(&x).Equals(W(&y)) || !W(&x).Equals(W(&x)) || W(&x).Hash() != W(&x).Hash() {
+		t.Errorf("expected pointers to compare by identity")
+	}
+
+	type iface struct{ V any }
+	if W(iface{1}).Hash() != W(iface{1}).Hash() {
+		t.Errorf("expected equal interface fields to hash equally")
+	}
+
+	type names struct{ A, B string }
+	for _, pair := range [][2]names{{{"ab", ""}, {"a", "b"}}, {{"", "ab"}, {"a", "b"}}, {{"a", "bc"}, {"ab", "c"}}} {
+		if W(pair[0]).Hash() == W(pair[1]).Hash() {
+			t.Errorf("expected %v and %v to hash differently", pair[0], pair[1])
+		}
+	}
+}